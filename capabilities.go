@@ -0,0 +1,163 @@
+package mcpgo
+
+import "context"
+
+// defaultProtocolVersion is the version mcp-go has always spoken and still
+// prefers unless WithProtocolVersion opts into a newer one.
+const defaultProtocolVersion = "2024-11-05"
+
+// defaultCapabilityMaps maps each protocol version mcp-go understands to the
+// ServerCapabilities it advertises for that version, similar in spirit to
+// etcd's capabilityMaps for cluster version negotiation. WithCapabilityOverride
+// replaces or adds an entry for a particular *MCPServer without touching
+// this default table.
+var defaultCapabilityMaps = map[string]ServerCapabilities{
+	"2024-11-05": {
+		Tools:     &ToolsCapability{ListChanged: boolPtr(true)},
+		Prompts:   &PromptsCapability{ListChanged: boolPtr(true)},
+		Resources: &ResourcesCapability{ListChanged: boolPtr(true)},
+	},
+	"2025-06-18": {
+		Tools:       &ToolsCapability{ListChanged: boolPtr(true)},
+		Prompts:     &PromptsCapability{ListChanged: boolPtr(true)},
+		Resources:   &ResourcesCapability{ListChanged: boolPtr(true)},
+		Logging:     &LoggingCapability{},
+		Completions: &CompletionsCapability{},
+	},
+}
+
+// WithProtocolVersion sets the protocol version the server prefers to
+// negotiate, e.g. "2025-06-18" to opt into completions, logging, and tool
+// output schemas. Defaults to "2024-11-05".
+func WithProtocolVersion(version string) ServerOption {
+	return func(s *MCPServer) {
+		s.protocolVersion = version
+	}
+}
+
+// WithCapabilityOverride replaces (or adds) the ServerCapabilities this
+// server advertises for version, without affecting any other server using
+// the built-in capability matrix.
+func WithCapabilityOverride(version string, caps ServerCapabilities) ServerOption {
+	return func(s *MCPServer) {
+		if s.capabilityOverrides == nil {
+			s.capabilityOverrides = make(map[string]ServerCapabilities)
+		}
+		s.capabilityOverrides[version] = caps
+	}
+}
+
+// capabilitiesForVersion returns the ServerCapabilities s advertises for
+// version, preferring a WithCapabilityOverride entry over the built-in
+// defaultCapabilityMaps, and whether version is one s recognizes at all.
+func (s *MCPServer) capabilitiesForVersion(version string) (ServerCapabilities, bool) {
+	if caps, ok := s.capabilityOverrides[version]; ok {
+		return caps, true
+	}
+	caps, ok := defaultCapabilityMaps[version]
+	return caps, ok
+}
+
+// preferredProtocolVersion is the version negotiateVersion falls back to
+// when the client's protocolVersion isn't one s recognizes.
+func (s *MCPServer) preferredProtocolVersion() string {
+	if s.protocolVersion != "" {
+		return s.protocolVersion
+	}
+	return defaultProtocolVersion
+}
+
+// negotiateVersion picks the protocol version and ServerCapabilities to
+// return from an initialize request carrying clientVersion: clientVersion's
+// own capability set if s recognizes it, otherwise s's preferred version
+// paired with the baseline (2024-11-05) capability set, since a client
+// asking for a version s doesn't recognize can't be assumed to understand
+// whatever the preferred version added.
+func (s *MCPServer) negotiateVersion(clientVersion string) (string, ServerCapabilities) {
+	if clientVersion != "" {
+		if caps, ok := s.capabilitiesForVersion(clientVersion); ok {
+			return clientVersion, caps
+		}
+	}
+	baseline, _ := s.capabilitiesForVersion(defaultProtocolVersion)
+	return s.preferredProtocolVersion(), baseline
+}
+
+// negotiatedVersionContextKey is an unexported type so values stashed under
+// it can't collide with keys set by other packages.
+type negotiatedVersionContextKey struct{}
+
+// contextWithNegotiatedVersion returns a copy of ctx carrying the protocol
+// version negotiated for the session ctx belongs to.
+func contextWithNegotiatedVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, negotiatedVersionContextKey{}, version)
+}
+
+// NegotiatedVersion returns the MCP protocol version negotiated during
+// initialize for ctx's session, so a tool, prompt, or resource handler can
+// branch on it - e.g. to decide whether the client can accept a response
+// field introduced after 2024-11-05. Returns "" if ctx wasn't passed
+// through RegisterTool/RegisterPrompt/RegisterResource dispatch, or if the
+// session hasn't completed initialize yet.
+func NegotiatedVersion(ctx context.Context) string {
+	version, _ := ctx.Value(negotiatedVersionContextKey{}).(string)
+	return version
+}
+
+// recordNegotiatedVersion remembers version as the negotiated version for
+// ctx's session (identified by the "session_id" value multiplexing
+// transports set), or as the server's single-session version if ctx
+// carries none.
+func (s *MCPServer) recordNegotiatedVersion(ctx context.Context, version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sessionID, ok := ctx.Value("session_id").(string); ok && sessionID != "" {
+		if s.sessionVersions == nil {
+			s.sessionVersions = make(map[string]string)
+		}
+		s.sessionVersions[sessionID] = version
+		return
+	}
+	s.defaultNegotiatedVersion = version
+}
+
+// withNegotiatedVersion returns a copy of ctx carrying the protocol version
+// negotiated for ctx's session, ready to retrieve via NegotiatedVersion.
+func (s *MCPServer) withNegotiatedVersion(ctx context.Context) context.Context {
+	s.mu.RLock()
+	version := s.defaultNegotiatedVersion
+	if sessionID, ok := ctx.Value("session_id").(string); ok && sessionID != "" {
+		version = s.sessionVersions[sessionID]
+	}
+	s.mu.RUnlock()
+	return contextWithNegotiatedVersion(ctx, version)
+}
+
+// supportsExtendedToolFields reports whether version's capability set
+// includes completions, a marker that it's new enough to also define
+// Tool.OutputSchema and Tool.Annotations.
+func (s *MCPServer) supportsExtendedToolFields(version string) bool {
+	caps, _ := s.capabilitiesForVersion(version)
+	return caps.Completions != nil
+}
+
+// ToolOption configures a tool at RegisterTool time.
+type ToolOption func(*registeredTool)
+
+// WithToolOutputSchema attaches a JSON Schema describing a tool's result,
+// advertised as Tool.OutputSchema to clients that negotiated a protocol
+// version new enough to define it.
+func WithToolOutputSchema(schema map[string]interface{}) ToolOption {
+	return func(t *registeredTool) {
+		t.outputSchema = schema
+	}
+}
+
+// WithToolAnnotations attaches client hints (e.g. read-only, destructive)
+// about a tool, advertised as Tool.Annotations to clients that negotiated a
+// protocol version new enough to define it.
+func WithToolAnnotations(annotations map[string]interface{}) ToolOption {
+	return func(t *registeredTool) {
+		t.annotations = annotations
+	}
+}
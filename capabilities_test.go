@@ -0,0 +1,98 @@
+package mcpgo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNegotiateVersion_RecognizedClientVersion(t *testing.T) {
+	s := &MCPServer{}
+
+	version, caps := s.negotiateVersion("2025-06-18")
+
+	if version != "2025-06-18" {
+		t.Fatalf("expected version 2025-06-18, got %q", version)
+	}
+	if caps.Completions == nil {
+		t.Fatalf("expected 2025-06-18 capabilities to include Completions")
+	}
+}
+
+func TestNegotiateVersion_UnrecognizedFallsBackToBaseline(t *testing.T) {
+	s := &MCPServer{}
+
+	version, caps := s.negotiateVersion("2099-01-01")
+
+	if version != defaultProtocolVersion {
+		t.Fatalf("expected fallback to %q, got %q", defaultProtocolVersion, version)
+	}
+	if caps.Completions != nil {
+		t.Fatalf("expected baseline capabilities to omit Completions")
+	}
+}
+
+func TestNegotiateVersion_PreferredVersionUsedOnFallback(t *testing.T) {
+	s := &MCPServer{protocolVersion: "2025-06-18"}
+
+	version, caps := s.negotiateVersion("2099-01-01")
+
+	if version != "2025-06-18" {
+		t.Fatalf("expected fallback to use preferred version 2025-06-18, got %q", version)
+	}
+	if caps.Completions != nil {
+		t.Fatalf("expected baseline capabilities even when falling back to a newer preferred version")
+	}
+}
+
+func TestCapabilitiesForVersion_OverrideWinsOverDefault(t *testing.T) {
+	custom := ServerCapabilities{Logging: &LoggingCapability{}}
+	s := &MCPServer{capabilityOverrides: map[string]ServerCapabilities{
+		defaultProtocolVersion: custom,
+	}}
+
+	caps, ok := s.capabilitiesForVersion(defaultProtocolVersion)
+	if !ok {
+		t.Fatalf("expected override version to be recognized")
+	}
+	if caps.Logging == nil || caps.Tools != nil {
+		t.Fatalf("expected override capabilities, got %+v", caps)
+	}
+}
+
+func TestNegotiatedVersion_RoundTripsPerSession(t *testing.T) {
+	s := &MCPServer{}
+
+	ctxA := context.WithValue(context.Background(), "session_id", "session-a")
+	ctxB := context.WithValue(context.Background(), "session_id", "session-b")
+
+	s.recordNegotiatedVersion(ctxA, "2024-11-05")
+	s.recordNegotiatedVersion(ctxB, "2025-06-18")
+
+	if got := NegotiatedVersion(s.withNegotiatedVersion(ctxA)); got != "2024-11-05" {
+		t.Fatalf("session-a: expected 2024-11-05, got %q", got)
+	}
+	if got := NegotiatedVersion(s.withNegotiatedVersion(ctxB)); got != "2025-06-18" {
+		t.Fatalf("session-b: expected 2025-06-18, got %q", got)
+	}
+}
+
+func TestNegotiatedVersion_DefaultsForSessionlessTransport(t *testing.T) {
+	s := &MCPServer{}
+
+	s.recordNegotiatedVersion(context.Background(), "2025-06-18")
+
+	if got := NegotiatedVersion(s.withNegotiatedVersion(context.Background())); got != "2025-06-18" {
+		t.Fatalf("expected 2025-06-18, got %q", got)
+	}
+}
+
+func TestSupportsExtendedToolFields(t *testing.T) {
+	s := &MCPServer{}
+
+	if s.supportsExtendedToolFields(defaultProtocolVersion) {
+		t.Fatalf("expected baseline version to not support extended tool fields")
+	}
+	if !s.supportsExtendedToolFields("2025-06-18") {
+		t.Fatalf("expected 2025-06-18 to support extended tool fields")
+	}
+}
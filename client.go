@@ -4,26 +4,163 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/DR1N0/mcp-go/observability"
 	"github.com/DR1N0/mcp-go/protocol"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Progress describes a single progress update for an in-flight request
+type Progress struct {
+	Progress float64
+	Total    *float64
+	Message  string
+}
+
 // mcpClient implements the Client interface
 type mcpClient struct {
-	transport    Transport
-	protocol     protocol.Protocol
-	capabilities *ServerCapabilities
-	initialized  bool
+	transport       Transport
+	protocol        protocol.Protocol
+	protocolOpts    []protocol.Option // Collected from options, consumed when the protocol is constructed
+	capabilities    *ServerCapabilities
+	initialized     bool
+	progressTokenID atomic.Int64
+	samplingHandler func(ctx context.Context, req SamplingRequest) (*SamplingResponse, error)
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+
+	retryOpts   ClientOptions
+	onReconnect func(attempt int, err error)
+	onGiveUp    func(err error)
+
+	subMu         sync.Mutex
+	subscriptions map[string]struct{} // active resource subscriptions, restored on reconnect
+}
+
+// ClientOptions configures retry and auto-reconnect behavior, installed via
+// WithClientOptions. The zero value disables both: requests fail on the
+// first error, and a dropped transport is never reconnected.
+type ClientOptions struct {
+	// MaxRetries is the number of additional attempts made for a failed
+	// idempotent request (initialize, */list, resources/read, ping) before
+	// giving up. Zero disables retries.
+	MaxRetries int
+
+	// BaseDelay and MaxDelay bound the exponential backoff between
+	// attempts: delay = min(MaxDelay, BaseDelay*2^attempt), with full
+	// jitter applied on top.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// AutoReconnect re-runs transport.Start and re-sends initialize (with
+	// the original clientInfo/protocolVersion, restoring any active
+	// resource subscriptions) before each retry, for use when a request
+	// failure means the underlying connection dropped.
+	AutoReconnect bool
+}
+
+// WithClientOptions installs a retry policy and, optionally, an
+// auto-reconnect loop for idempotent requests: initialize, */list,
+// resources/read, and ping
+func WithClientOptions(opts ClientOptions) ClientOption {
+	return func(c *mcpClient) {
+		c.retryOpts = opts
+	}
+}
+
+// ClientOption configures the client
+type ClientOption func(*mcpClient)
+
+// WithClientInterceptors chains interceptors, in order, around every
+// outgoing Request call - useful for cross-cutting concerns like auth,
+// logging, retries, or rate limiting
+func WithClientInterceptors(interceptors ...protocol.ClientInterceptor) ClientOption {
+	return func(c *mcpClient) {
+		c.protocolOpts = append(c.protocolOpts, protocol.WithClientInterceptors(interceptors...))
+	}
+}
+
+// WithClientNotificationInterceptors chains interceptors, in order, around
+// every outgoing Notification call
+func WithClientNotificationInterceptors(interceptors ...protocol.ClientNotificationInterceptor) ClientOption {
+	return func(c *mcpClient) {
+		c.protocolOpts = append(c.protocolOpts, protocol.WithClientNotificationInterceptors(interceptors...))
+	}
+}
+
+// WithClientTracerProvider installs OpenTelemetry tracing around every
+// outgoing request, via observability.NewClientInterceptor - one span per
+// call, named "mcp.tool/<name>" for tools/call and "mcp.<method>"
+// otherwise, with its traceparent stamped into the request's "_meta" so the
+// server can continue the same trace. Defaults to the globally registered
+// TracerProvider (a no-op tracer if none was set).
+func WithClientTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *mcpClient) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithClientMeterProvider installs request count, duration, in-flight, and
+// per-error-code metrics around every outgoing request, via
+// observability.NewClientInterceptor. Defaults to the globally registered
+// MeterProvider (a no-op meter if none was set).
+func WithClientMeterProvider(mp metric.MeterProvider) ClientOption {
+	return func(c *mcpClient) {
+		c.meterProvider = mp
+	}
+}
+
+// WithSamplingHandler is NewClient's functional-option form of
+// SetSamplingHandler, for applications that prefer to configure the
+// sampling handler up front rather than with a separate call.
+func WithSamplingHandler(handler func(ctx context.Context, req SamplingRequest) (*SamplingResponse, error)) ClientOption {
+	return func(c *mcpClient) {
+		c.samplingHandler = handler
+	}
 }
 
 // NewClient creates a new MCP client that returns the Client interface
-func NewClient(transport Transport) Client {
-	return &mcpClient{
-		transport:   transport,
-		protocol:    protocol.NewProtocol(),
-		initialized: false,
+func NewClient(transport Transport, opts ...ClientOption) Client {
+	c := &mcpClient{
+		transport:     transport,
+		initialized:   false,
+		subscriptions: make(map[string]struct{}),
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.tracerProvider != nil || c.meterProvider != nil {
+		c.protocolOpts = append(c.protocolOpts, protocol.WithClientInterceptors(
+			observability.NewClientInterceptor(
+				observability.WithTracerProvider(c.tracerProvider),
+				observability.WithMeterProvider(c.meterProvider),
+				observability.WithTransportKind(fmt.Sprintf("%T", transport)),
+			),
+		))
+	}
+
+	c.protocol = protocol.NewProtocol(c.protocolOpts...)
+
+	return c
+}
+
+// SetSamplingHandler registers the handler used to service
+// sampling/createMessage requests from the server. Must be called before
+// Initialize: the sampling capability is only advertised to the server
+// when a handler is registered. See also WithSamplingHandler to configure
+// this at construction time instead.
+func (c *mcpClient) SetSamplingHandler(handler func(ctx context.Context, req SamplingRequest) (*SamplingResponse, error)) {
+	c.samplingHandler = handler
 }
 
 // Initialize connects to the server and retrieves its capabilities
@@ -37,10 +174,32 @@ func (c *mcpClient) Initialize(ctx context.Context) (*InitializeResponse, error)
 		return nil, fmt.Errorf("failed to connect protocol: %w", err)
 	}
 
-	// Send initialize request
+	result, err := c.withRetry(ctx, func() (interface{}, error) {
+		return c.doInitialize(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	initResp := result.(*InitializeResponse)
+
+	c.initialized = true
+	log.Printf("Initialized MCP client: server=%s v%s", initResp.ServerInfo.Name, initResp.ServerInfo.Version)
+	return initResp, nil
+}
+
+// doInitialize sends the initialize request and records the returned
+// capabilities, without touching c.initialized - shared by Initialize and
+// reconnect, which re-initializes an already-initialized client.
+func (c *mcpClient) doInitialize(ctx context.Context) (*InitializeResponse, error) {
+	capabilities := ClientCapabilities{}
+	if c.samplingHandler != nil {
+		capabilities.Sampling = &SamplingCapability{}
+		c.protocol.SetRequestHandler("sampling/createMessage", c.handleCreateMessage)
+	}
+
 	params := map[string]interface{}{
 		"protocolVersion": "2025-12-25",
-		"capabilities":    map[string]interface{}{},
+		"capabilities":    capabilities,
 		"clientInfo": map[string]interface{}{
 			"name":    "mcp-go-client",
 			"version": "0.1.0",
@@ -52,19 +211,143 @@ func (c *mcpClient) Initialize(ctx context.Context) (*InitializeResponse, error)
 		return nil, fmt.Errorf("initialize request failed: %w", err)
 	}
 
-	// Parse initialize response
 	var initResp InitializeResponse
 	if err := unmarshalResponse(response, &initResp); err != nil {
 		return nil, fmt.Errorf("failed to parse initialize response: %w", err)
 	}
 
 	c.capabilities = &initResp.Capabilities
-	c.initialized = true
-
-	log.Printf("Initialized MCP client: server=%s v%s", initResp.ServerInfo.Name, initResp.ServerInfo.Version)
 	return &initResp, nil
 }
 
+// reconnect restarts the underlying transport, re-runs the initialize
+// handshake with the same clientInfo/protocolVersion, and re-subscribes to
+// every resource currently tracked in c.subscriptions
+func (c *mcpClient) reconnect(ctx context.Context) error {
+	if err := c.transport.Start(ctx); err != nil {
+		return fmt.Errorf("failed to restart transport: %w", err)
+	}
+
+	if _, err := c.doInitialize(ctx); err != nil {
+		return fmt.Errorf("failed to re-initialize after reconnect: %w", err)
+	}
+
+	c.subMu.Lock()
+	uris := make([]string, 0, len(c.subscriptions))
+	for uri := range c.subscriptions {
+		uris = append(uris, uri)
+	}
+	c.subMu.Unlock()
+
+	for _, uri := range uris {
+		if _, err := c.protocol.Request(ctx, "resources/subscribe", map[string]interface{}{"uri": uri}); err != nil {
+			return fmt.Errorf("failed to restore subscription for %s: %w", uri, err)
+		}
+	}
+
+	return nil
+}
+
+// withRetry runs fn, retrying up to c.retryOpts.MaxRetries times with full
+// jitter exponential backoff on failure - used only for idempotent
+// requests (initialize, */list, resources/read, ping) where re-sending on
+// error can't cause a duplicate side effect. When c.retryOpts.AutoReconnect
+// is set, it reconnects the transport before each retry.
+func (c *mcpClient) withRetry(ctx context.Context, fn func() (interface{}, error)) (interface{}, error) {
+	if c.retryOpts.MaxRetries <= 0 {
+		return fn()
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.retryOpts.MaxRetries; attempt++ {
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if attempt == c.retryOpts.MaxRetries {
+			break
+		}
+
+		if c.onReconnect != nil {
+			c.onReconnect(attempt+1, err)
+		}
+
+		if c.retryOpts.AutoReconnect {
+			if rerr := c.reconnect(ctx); rerr != nil {
+				lastErr = rerr
+			}
+		}
+
+		if err := sleepWithJitter(ctx, c.retryOpts.BaseDelay, c.retryOpts.MaxDelay, attempt); err != nil {
+			lastErr = err
+			break
+		}
+	}
+
+	if c.onGiveUp != nil {
+		c.onGiveUp(lastErr)
+	}
+	return nil, lastErr
+}
+
+// sleepWithJitter waits for min(maxDelay, baseDelay*2^attempt), then
+// applies full jitter (a uniform random draw between 0 and that delay), or
+// returns ctx.Err() if ctx is done first
+func sleepWithJitter(ctx context.Context, baseDelay, maxDelay time.Duration, attempt int) error {
+	delay := baseDelay << attempt
+	if maxDelay > 0 && (delay > maxDelay || delay < 0) {
+		delay = maxDelay
+	}
+	if delay > 0 {
+		delay = time.Duration(rand.Int63n(int64(delay) + 1))
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnReconnect registers handler to be called before each retry attempt
+// (whether or not auto-reconnect is enabled), receiving the 1-based attempt
+// number and the error that triggered it
+func (c *mcpClient) OnReconnect(handler func(attempt int, err error)) {
+	c.onReconnect = handler
+}
+
+// OnGiveUp registers handler to be called with the final error once an
+// idempotent request has exhausted its retries
+func (c *mcpClient) OnGiveUp(handler func(err error)) {
+	c.onGiveUp = handler
+}
+
+// handleCreateMessage is the protocol-level request handler wired up for
+// sampling/createMessage when a sampling handler has been registered; it
+// unmarshals the request, delegates to the application's handler, and
+// returns the response for the protocol layer to send back to the server
+func (c *mcpClient) handleCreateMessage(ctx context.Context, params interface{}) (interface{}, error) {
+	var req SamplingRequest
+	if err := unmarshalResponse(params, &req); err != nil {
+		return nil, fmt.Errorf("failed to parse sampling/createMessage params: %w", err)
+	}
+
+	resp, err := c.samplingHandler(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("sampling handler failed: %w", err)
+	}
+
+	return resp, nil
+}
+
 // ListTools retrieves the list of available tools from the server
 func (c *mcpClient) ListTools(ctx context.Context, cursor *string) (*ToolsResponse, error) {
 	if !c.initialized {
@@ -76,7 +359,9 @@ func (c *mcpClient) ListTools(ctx context.Context, cursor *string) (*ToolsRespon
 		params["cursor"] = *cursor
 	}
 
-	response, err := c.protocol.Request(ctx, "tools/list", params)
+	response, err := c.withRetry(ctx, func() (interface{}, error) {
+		return c.protocol.Request(ctx, "tools/list", params)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("tools/list request failed: %w", err)
 	}
@@ -113,6 +398,147 @@ func (c *mcpClient) CallTool(ctx context.Context, name string, args interface{})
 	return &toolResp, nil
 }
 
+// CallToolWithProgress calls a specific tool like CallTool, but additionally
+// invokes onProgress for every notifications/progress message the server
+// sends for this call before the final response arrives
+func (c *mcpClient) CallToolWithProgress(ctx context.Context, name string, args interface{}, onProgress func(Progress)) (*ToolResponse, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	token, cleanup := c.registerProgress(onProgress)
+	defer cleanup()
+
+	params := map[string]interface{}{
+		"name":      name,
+		"arguments": args,
+		"_meta": map[string]interface{}{
+			"progressToken": token,
+		},
+	}
+
+	response, err := c.protocol.Request(ctx, "tools/call", params)
+	if err != nil {
+		return nil, fmt.Errorf("tools/call request failed: %w", err)
+	}
+
+	var toolResp ToolResponse
+	if err := unmarshalResponse(response, &toolResp); err != nil {
+		return nil, fmt.Errorf("failed to parse tools/call response: %w", err)
+	}
+
+	return &toolResp, nil
+}
+
+// registerProgress allocates a fresh progress token and, if onProgress is
+// non-nil, routes matching notifications/progress messages to it until the
+// returned cleanup func is called. Put the returned token into a request's
+// params._meta.progressToken to opt into progress tracking. The token is
+// not part of the notifications/cancelled the protocol layer sends if ctx
+// is canceled first: that notification names the call by request id only.
+func (c *mcpClient) registerProgress(onProgress func(Progress)) (token int64, cleanup func()) {
+	token = c.progressTokenID.Add(1)
+	if onProgress == nil {
+		return token, func() {}
+	}
+
+	c.protocol.RegisterProgressHandler(token, func(progress float64, total *float64, message string) {
+		onProgress(Progress{Progress: progress, Total: total, Message: message})
+	})
+	return token, func() { c.protocol.UnregisterProgressHandler(token) }
+}
+
+// ToolEventType classifies a single event delivered on CallToolStream's
+// channel.
+type ToolEventType int
+
+const (
+	// ToolEventProgress carries a progress update, like CallToolWithProgress's
+	// onProgress callback.
+	ToolEventProgress ToolEventType = iota
+	// ToolEventPartial carries incremental content pushed by the tool's
+	// ToolStream.
+	ToolEventPartial
+	// ToolEventFinal carries the tool's final response and is always the last
+	// event sent before the channel closes, unless the call itself failed.
+	ToolEventFinal
+	// ToolEventError carries a terminal error and is always the last event
+	// sent before the channel closes, in place of a ToolEventFinal.
+	ToolEventError
+)
+
+// ToolEvent is a single event delivered by CallToolStream.
+type ToolEvent struct {
+	Type ToolEventType
+
+	// Set on ToolEventProgress
+	Progress float64
+	Total    *float64
+	Message  string
+
+	// Set on ToolEventPartial
+	Content []Content
+
+	// Set on ToolEventFinal
+	Response *ToolResponse
+
+	// Set on ToolEventError
+	Err error
+}
+
+// CallToolStream calls a specific tool like CallTool, but returns a channel
+// of ToolEvent that streams progress updates and incremental content as the
+// server's tool handler produces them, terminated by exactly one
+// ToolEventFinal or ToolEventError event before the channel is closed.
+func (c *mcpClient) CallToolStream(ctx context.Context, name string, args interface{}) (<-chan ToolEvent, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	token := c.progressTokenID.Add(1)
+	events := make(chan ToolEvent, 16)
+
+	c.protocol.RegisterProgressHandler(token, func(progress float64, total *float64, message string) {
+		events <- ToolEvent{Type: ToolEventProgress, Progress: progress, Total: total, Message: message}
+	})
+	c.protocol.RegisterPartialHandler(token, func(raw interface{}) {
+		var content []Content
+		if err := unmarshalResponse(raw, &content); err != nil {
+			return
+		}
+		events <- ToolEvent{Type: ToolEventPartial, Content: content}
+	})
+
+	params := map[string]interface{}{
+		"name":      name,
+		"arguments": args,
+		"_meta": map[string]interface{}{
+			"progressToken": token,
+		},
+	}
+
+	go func() {
+		defer close(events)
+		defer c.protocol.UnregisterProgressHandler(token)
+		defer c.protocol.UnregisterPartialHandler(token)
+
+		response, err := c.protocol.Request(ctx, "tools/call", params)
+		if err != nil {
+			events <- ToolEvent{Type: ToolEventError, Err: fmt.Errorf("tools/call request failed: %w", err)}
+			return
+		}
+
+		var toolResp ToolResponse
+		if err := unmarshalResponse(response, &toolResp); err != nil {
+			events <- ToolEvent{Type: ToolEventError, Err: fmt.Errorf("failed to parse tools/call response: %w", err)}
+			return
+		}
+		events <- ToolEvent{Type: ToolEventFinal, Response: &toolResp}
+	}()
+
+	return events, nil
+}
+
 // ListPrompts retrieves the list of available prompts from the server
 func (c *mcpClient) ListPrompts(ctx context.Context, cursor *string) (*ListPromptsResponse, error) {
 	if !c.initialized {
@@ -124,7 +550,9 @@ func (c *mcpClient) ListPrompts(ctx context.Context, cursor *string) (*ListPromp
 		params["cursor"] = *cursor
 	}
 
-	response, err := c.protocol.Request(ctx, "prompts/list", params)
+	response, err := c.withRetry(ctx, func() (interface{}, error) {
+		return c.protocol.Request(ctx, "prompts/list", params)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("prompts/list request failed: %w", err)
 	}
@@ -161,6 +589,38 @@ func (c *mcpClient) GetPrompt(ctx context.Context, name string, args interface{}
 	return &promptResp, nil
 }
 
+// GetPromptWithProgress calls GetPrompt like normal, but additionally
+// invokes onProgress for every notifications/progress message the server
+// sends for this call before the response arrives
+func (c *mcpClient) GetPromptWithProgress(ctx context.Context, name string, args interface{}, onProgress func(Progress)) (*PromptResponse, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	token, cleanup := c.registerProgress(onProgress)
+	defer cleanup()
+
+	params := map[string]interface{}{
+		"name":      name,
+		"arguments": args,
+		"_meta": map[string]interface{}{
+			"progressToken": token,
+		},
+	}
+
+	response, err := c.protocol.Request(ctx, "prompts/get", params)
+	if err != nil {
+		return nil, fmt.Errorf("prompts/get request failed: %w", err)
+	}
+
+	var promptResp PromptResponse
+	if err := unmarshalResponse(response, &promptResp); err != nil {
+		return nil, fmt.Errorf("failed to parse prompts/get response: %w", err)
+	}
+
+	return &promptResp, nil
+}
+
 // ListResources retrieves the list of available resources from the server
 func (c *mcpClient) ListResources(ctx context.Context, cursor *string) (*ListResourcesResponse, error) {
 	if !c.initialized {
@@ -172,7 +632,9 @@ func (c *mcpClient) ListResources(ctx context.Context, cursor *string) (*ListRes
 		params["cursor"] = *cursor
 	}
 
-	response, err := c.protocol.Request(ctx, "resources/list", params)
+	response, err := c.withRetry(ctx, func() (interface{}, error) {
+		return c.protocol.Request(ctx, "resources/list", params)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("resources/list request failed: %w", err)
 	}
@@ -195,7 +657,9 @@ func (c *mcpClient) ReadResource(ctx context.Context, uri string) (*ResourceResp
 		"uri": uri,
 	}
 
-	response, err := c.protocol.Request(ctx, "resources/read", params)
+	response, err := c.withRetry(ctx, func() (interface{}, error) {
+		return c.protocol.Request(ctx, "resources/read", params)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("resources/read request failed: %w", err)
 	}
@@ -208,13 +672,301 @@ func (c *mcpClient) ReadResource(ctx context.Context, uri string) (*ResourceResp
 	return &resourceResp, nil
 }
 
+// ReadResourceWithProgress calls ReadResource like normal, but additionally
+// invokes onProgress for every notifications/progress message the server
+// sends for this call before the response arrives
+func (c *mcpClient) ReadResourceWithProgress(ctx context.Context, uri string, onProgress func(Progress)) (*ResourceResponse, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	token, cleanup := c.registerProgress(onProgress)
+	defer cleanup()
+
+	params := map[string]interface{}{
+		"uri": uri,
+		"_meta": map[string]interface{}{
+			"progressToken": token,
+		},
+	}
+
+	response, err := c.withRetry(ctx, func() (interface{}, error) {
+		return c.protocol.Request(ctx, "resources/read", params)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resources/read request failed: %w", err)
+	}
+
+	var resourceResp ResourceResponse
+	if err := unmarshalResponse(response, &resourceResp); err != nil {
+		return nil, fmt.Errorf("failed to parse resources/read response: %w", err)
+	}
+
+	return &resourceResp, nil
+}
+
+// defaultMaxPages caps ListAllTools' pagination loop so a misbehaving
+// server that never returns a nil NextCursor can't grow the result slice
+// without bound.
+const defaultMaxPages = 1000
+
+// IterateTools pages through ListTools, following NextCursor until the
+// server stops returning one, and yields each tool in turn. Iteration stops
+// early - yielding a final (Tool{}, err) pair - if ctx is canceled or a
+// ListTools call fails; range over the sequence to consume it:
+//
+//	for tool, err := range client.IterateTools(ctx) {
+//		if err != nil { ... }
+//	}
+func (c *mcpClient) IterateTools(ctx context.Context) iter.Seq2[Tool, error] {
+	return func(yield func(Tool, error) bool) {
+		var cursor *string
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Tool{}, err)
+				return
+			}
+
+			resp, err := c.ListTools(ctx, cursor)
+			if err != nil {
+				yield(Tool{}, err)
+				return
+			}
+
+			for _, tool := range resp.Tools {
+				if !yield(tool, nil) {
+					return
+				}
+			}
+
+			if resp.NextCursor == nil {
+				return
+			}
+			cursor = resp.NextCursor
+		}
+	}
+}
+
+// IteratePrompts is IterateTools' counterpart for ListPrompts.
+func (c *mcpClient) IteratePrompts(ctx context.Context) iter.Seq2[Prompt, error] {
+	return func(yield func(Prompt, error) bool) {
+		var cursor *string
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Prompt{}, err)
+				return
+			}
+
+			resp, err := c.ListPrompts(ctx, cursor)
+			if err != nil {
+				yield(Prompt{}, err)
+				return
+			}
+
+			for _, prompt := range resp.Prompts {
+				if !yield(prompt, nil) {
+					return
+				}
+			}
+
+			if resp.NextCursor == nil {
+				return
+			}
+			cursor = resp.NextCursor
+		}
+	}
+}
+
+// IterateResources is IterateTools' counterpart for ListResources.
+func (c *mcpClient) IterateResources(ctx context.Context) iter.Seq2[Resource, error] {
+	return func(yield func(Resource, error) bool) {
+		var cursor *string
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Resource{}, err)
+				return
+			}
+
+			resp, err := c.ListResources(ctx, cursor)
+			if err != nil {
+				yield(Resource{}, err)
+				return
+			}
+
+			for _, resource := range resp.Resources {
+				if !yield(resource, nil) {
+					return
+				}
+			}
+
+			if resp.NextCursor == nil {
+				return
+			}
+			cursor = resp.NextCursor
+		}
+	}
+}
+
+// ListAllTools materializes every page of ListTools into a single slice,
+// giving up with an error once maxPages pages have been
+// fetched without exhausting NextCursor - protection against an unbounded
+// loop against a misbehaving server. maxPages <= 0 uses defaultMaxPages.
+func (c *mcpClient) ListAllTools(ctx context.Context, maxPages int) ([]Tool, error) {
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	var tools []Tool
+	pages := 0
+	cursor := (*string)(nil)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pages++
+		if pages > maxPages {
+			return nil, fmt.Errorf("ListAllTools: exceeded maxPages (%d) without exhausting NextCursor", maxPages)
+		}
+
+		resp, err := c.ListTools(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, resp.Tools...)
+
+		if resp.NextCursor == nil {
+			return tools, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// Subscribe asks the server to send notifications/resources/updated
+// messages for uri until Unsubscribe is called. Fails with a clear error if
+// the server didn't advertise the resources.subscribe capability during
+// Initialize.
+func (c *mcpClient) Subscribe(ctx context.Context, uri string) error {
+	if !c.initialized {
+		return fmt.Errorf("client not initialized")
+	}
+	if !c.supportsResourceSubscribe() {
+		return fmt.Errorf("server does not support resource subscriptions")
+	}
+
+	params := map[string]interface{}{
+		"uri": uri,
+	}
+
+	if _, err := c.protocol.Request(ctx, "resources/subscribe", params); err != nil {
+		return fmt.Errorf("resources/subscribe request failed: %w", err)
+	}
+
+	c.subMu.Lock()
+	c.subscriptions[uri] = struct{}{}
+	c.subMu.Unlock()
+
+	return nil
+}
+
+// Unsubscribe stops notifications/resources/updated messages for uri that
+// were started with Subscribe.
+func (c *mcpClient) Unsubscribe(ctx context.Context, uri string) error {
+	if !c.initialized {
+		return fmt.Errorf("client not initialized")
+	}
+	if !c.supportsResourceSubscribe() {
+		return fmt.Errorf("server does not support resource subscriptions")
+	}
+
+	params := map[string]interface{}{
+		"uri": uri,
+	}
+
+	if _, err := c.protocol.Request(ctx, "resources/unsubscribe", params); err != nil {
+		return fmt.Errorf("resources/unsubscribe request failed: %w", err)
+	}
+
+	c.subMu.Lock()
+	delete(c.subscriptions, uri)
+	c.subMu.Unlock()
+
+	return nil
+}
+
+// supportsResourceSubscribe reports whether the server advertised the
+// resources.subscribe capability during Initialize.
+func (c *mcpClient) supportsResourceSubscribe() bool {
+	return c.capabilities != nil &&
+		c.capabilities.Resources != nil &&
+		c.capabilities.Resources.Subscribe != nil &&
+		*c.capabilities.Resources.Subscribe
+}
+
+// OnResourceUpdated registers handler to be called with the URI carried by
+// every notifications/resources/updated message the server sends, e.g. for
+// a resource subscribed to via Subscribe.
+func (c *mcpClient) OnResourceUpdated(handler func(uri string)) {
+	c.protocol.SetNotificationHandler("notifications/resources/updated", func(params interface{}) error {
+		paramsMap, ok := params.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid resources/updated notification params")
+		}
+		uri, _ := paramsMap["uri"].(string)
+		handler(uri)
+		return nil
+	})
+}
+
+// OnToolsListChanged registers handler to be called whenever the server
+// sends a notifications/tools/list_changed message, signaling that a
+// subsequent ListTools call would return a different set of tools.
+func (c *mcpClient) OnToolsListChanged(handler func()) {
+	c.protocol.SetNotificationHandler("notifications/tools/list_changed", func(params interface{}) error {
+		handler()
+		return nil
+	})
+}
+
+// OnPromptsListChanged registers handler to be called whenever the server
+// sends a notifications/prompts/list_changed message, signaling that a
+// subsequent ListPrompts call would return a different set of prompts.
+func (c *mcpClient) OnPromptsListChanged(handler func()) {
+	c.protocol.SetNotificationHandler("notifications/prompts/list_changed", func(params interface{}) error {
+		handler()
+		return nil
+	})
+}
+
+// OnResourcesListChanged registers handler to be called whenever the server
+// sends a notifications/resources/list_changed message, signaling that a
+// subsequent ListResources call would return a different set of resources.
+func (c *mcpClient) OnResourcesListChanged(handler func()) {
+	c.protocol.SetNotificationHandler("notifications/resources/list_changed", func(params interface{}) error {
+		handler()
+		return nil
+	})
+}
+
+// Batch issues several requests as a single JSON-RPC batch, e.g. to fetch
+// tools, prompts, and resources in one round-trip
+func (c *mcpClient) Batch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	return c.protocol.Batch(ctx, calls)
+}
+
 // Ping sends a ping request to check server connectivity
 func (c *mcpClient) Ping(ctx context.Context) error {
 	if !c.initialized {
 		return fmt.Errorf("client not initialized")
 	}
 
-	_, err := c.protocol.Request(ctx, "ping", nil)
+	_, err := c.withRetry(ctx, func() (interface{}, error) {
+		return c.protocol.Request(ctx, "ping", nil)
+	})
 	if err != nil {
 		return fmt.Errorf("ping request failed: %w", err)
 	}
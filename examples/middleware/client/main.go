@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	mcpgo "github.com/DR1N0/mcp-go"
+	"github.com/DR1N0/mcp-go/protocol"
 	"github.com/DR1N0/mcp-go/transport/streamable"
 )
 
@@ -58,9 +59,13 @@ func testWithAuth(serverURL string) {
 		},
 	}
 
-	// Create transport with custom HTTP client
+	// Create transport with custom HTTP client. The Bearer token still has to
+	// be attached at the HTTP layer via authTransport, since the server's
+	// auth middleware inspects it before a JSON-RPC message is even parsed;
+	// the client interceptor below only demonstrates logging at the JSON-RPC
+	// layer, on top of that transport-level auth.
 	transport := streamable.NewClientTransport(serverURL, streamable.WithHTTPClient(httpClient))
-	client := mcpgo.NewClient(transport)
+	client := mcpgo.NewClient(transport, mcpgo.WithClientInterceptors(loggingInterceptor))
 
 	ctx := context.Background()
 
@@ -110,6 +115,14 @@ func testWithAuth(serverURL string) {
 	}
 }
 
+// loggingInterceptor logs every outgoing JSON-RPC request and how it resolved
+func loggingInterceptor(ctx context.Context, method string, params interface{}, next protocol.RequestHandler) (interface{}, error) {
+	log.Printf("→ %s", method)
+	result, err := next(ctx, params)
+	log.Printf("← %s (error=%v)", method, err)
+	return result, err
+}
+
 // authTransport wraps http.RoundTripper to add Authorization header
 type authTransport struct {
 	token string
@@ -9,6 +9,7 @@ import (
 	"syscall"
 
 	mcpgo "github.com/DR1N0/mcp-go"
+	"github.com/DR1N0/mcp-go/protocol"
 	"github.com/DR1N0/mcp-go/transport/streamable"
 )
 
@@ -33,14 +34,6 @@ func authMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// loggingMiddleware logs all requests
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("→ [%s] %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
-		next.ServeHTTP(w, r)
-	})
-}
-
 // corsMiddleware adds CORS headers
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -76,13 +69,15 @@ func main() {
 	// Create transport with middleware chain
 	transport := streamable.NewServerTransport("/mcp", ":8080").
 		WithMiddleware(authMiddleware).
-		WithMiddleware(loggingMiddleware).
 		WithMiddleware(corsMiddleware)
 
 	server := mcpgo.NewServer(
 		transport,
 		mcpgo.WithName("middleware-example"),
 		mcpgo.WithVersion("1.0.0"),
+		// Request logging moves from an HTTP middleware to a protocol
+		// interceptor, so it also covers non-HTTP transports.
+		mcpgo.WithServerInterceptors(protocol.LoggingInterceptor(log.Default()), protocol.RecoveryInterceptor()),
 	)
 
 	if err := server.RegisterTool("echo", "Echoes a message", echoTool); err != nil {
@@ -95,7 +90,7 @@ func main() {
 
 	fmt.Println("✅ Server started with middleware:")
 	fmt.Println("   • CORS enabled")
-	fmt.Println("   • Request logging")
+	fmt.Println("   • Request logging (protocol interceptor)")
 	fmt.Println("   • Bearer token auth")
 	fmt.Println()
 	fmt.Println("Running on: http://localhost:8080/mcp")
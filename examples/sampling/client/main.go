@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	mcpgo "github.com/DR1N0/mcp-go"
+	"github.com/DR1N0/mcp-go/transport/streamable"
+)
+
+func main() {
+	fmt.Println("=================================================================================")
+	fmt.Println("MCP Client - Bidirectional Sampling")
+	fmt.Println("=================================================================================")
+	fmt.Println()
+
+	transport := streamable.NewClientTransport("http://localhost:8090/mcp")
+	client := mcpgo.NewClient(transport)
+
+	// Plug in a stand-in for a local LLM. A real application would route
+	// this to something like a local model runtime or a hosted completion API.
+	client.SetSamplingHandler(func(ctx context.Context, req mcpgo.SamplingRequest) (*mcpgo.SamplingResponse, error) {
+		log.Printf("Received sampling request with %d message(s)", len(req.Messages))
+		return &mcpgo.SamplingResponse{
+			Role:       mcpgo.RoleAssistant,
+			Content:    *mcpgo.NewTextContent("This is a stand-in summary produced by the client's LLM."),
+			Model:      "stand-in-llm",
+			StopReason: mcpgo.StopReasonEndTurn,
+		}, nil
+	})
+
+	ctx := context.Background()
+	result, err := client.Initialize(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize: %v", err)
+	}
+	fmt.Printf("✅ Connected to server: %s v%s\n", result.ServerInfo.Name, result.ServerInfo.Version)
+
+	response, err := client.CallTool(ctx, "summarize", map[string]interface{}{
+		"text": "The quick brown fox jumps over the lazy dog, repeatedly, for reasons nobody quite remembers.",
+	})
+	if err != nil {
+		log.Fatalf("Failed to call tool: %v", err)
+	}
+
+	if len(response.Content) > 0 && response.Content[0].Text != nil {
+		fmt.Printf("✅ Tool response: %s\n", *response.Content[0].Text)
+	}
+
+	if err := client.Close(); err != nil {
+		log.Printf("Error closing client: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	mcpgo "github.com/DR1N0/mcp-go"
+	"github.com/DR1N0/mcp-go/transport/streamable"
+)
+
+// SummarizeArgs is the input to the summarize tool
+type SummarizeArgs struct {
+	Text string `json:"text" jsonschema:"required,description=The text to summarize"`
+}
+
+var server mcpgo.Server
+
+// summarizeTool doesn't run an LLM itself - it asks the connected client to
+// sample a completion and returns that as the tool's result. This only
+// works against clients that registered a sampling handler.
+func summarizeTool(ctx context.Context, args SummarizeArgs) (*mcpgo.ToolResponse, error) {
+	resp, err := server.RequestSampling(ctx, mcpgo.SamplingRequest{
+		Messages: []mcpgo.SamplingMessage{
+			{
+				Role:    mcpgo.RoleUser,
+				Content: *mcpgo.NewTextContent(fmt.Sprintf("Summarize this in one sentence:\n\n%s", args.Text)),
+			},
+		},
+		MaxTokens: 256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sampling request failed: %w", err)
+	}
+
+	if resp.Content.Text == nil {
+		return nil, fmt.Errorf("client returned non-text sampling response")
+	}
+
+	return mcpgo.NewToolResponse(mcpgo.NewTextContent(*resp.Content.Text)), nil
+}
+
+func main() {
+	fmt.Println("=================================================================================")
+	fmt.Println("MCP Server - Bidirectional Sampling")
+	fmt.Println("=================================================================================")
+	fmt.Println()
+
+	server = mcpgo.NewServer(
+		streamable.NewServerTransport("/mcp", ":8090"),
+		mcpgo.WithName("sampling-example-server"),
+		mcpgo.WithVersion("1.0.0"),
+	)
+
+	if err := server.RegisterTool("summarize", "Summarizes text using the client's LLM", summarizeTool); err != nil {
+		log.Fatalf("Failed to register summarize tool: %v", err)
+	}
+
+	if err := server.Serve(); err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+
+	fmt.Println("✅ Server running on http://localhost:8090/mcp")
+	fmt.Println("   • summarize tool asks the client to sample a completion")
+	fmt.Println()
+	fmt.Println("Press Ctrl+C to stop...")
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	<-sigChan
+
+	fmt.Println("\nShutting down...")
+	server.Close()
+}
@@ -1,6 +1,9 @@
 package mcpgo
 
-import "context"
+import (
+	"context"
+	"iter"
+)
 
 // Server represents an MCP server that can register and serve tools, prompts, and resources
 type Server interface {
@@ -9,13 +12,20 @@ type Server interface {
 	// func(ctx context.Context, args YourArgsStruct) (*ToolResponse, error)
 	// or
 	// func(args YourArgsStruct) (*ToolResponse, error)
-	RegisterTool(name, description string, handler interface{}) error
+	// The handler may additionally take a trailing ProgressReporter argument
+	// to report progress on long-running calls, e.g.
+	// func(args YourArgsStruct, report ProgressReporter) (*ToolResponse, error)
+	// opts may include WithToolOutputSchema and WithToolAnnotations, only
+	// advertised to clients that negotiated a protocol version new enough
+	// to define them.
+	RegisterTool(name, description string, handler interface{}, opts ...ToolOption) error
 
 	// RegisterPrompt registers a new prompt with the server
 	RegisterPrompt(name, description string, handler interface{}) error
 
-	// RegisterResource registers a new resource with the server
-	RegisterResource(uri, name, description, mimeType string, handler interface{}) error
+	// RegisterResource registers a new resource with the server. opts may
+	// include WithResourceWatcher to back resources/subscribe for this uri.
+	RegisterResource(uri, name, description, mimeType string, handler interface{}, opts ...ResourceOption) error
 
 	// DeregisterTool removes a tool from the server
 	DeregisterTool(name string) error
@@ -35,6 +45,20 @@ type Server interface {
 	// HasResource checks if a resource is registered
 	HasResource(uri string) bool
 
+	// NotifyResourceUpdated sends a notifications/resources/updated message
+	// for uri to every session currently subscribed to it. Typically called
+	// from a ResourceWatcher, but safe to call directly.
+	NotifyResourceUpdated(uri string)
+
+	// RequestSampling asks the connected client to run its LLM over the
+	// given messages, e.g. from within a tool handler that needs a
+	// completion. The client must have advertised the sampling capability
+	// during Initialize, and the request is routed to whichever client sent
+	// the ctx's in-flight request. A ctx that carries no session is not
+	// rejected: the request goes wherever the transport sends messages that
+	// name no session, which may be the wrong client.
+	RequestSampling(ctx context.Context, req SamplingRequest) (*SamplingResponse, error)
+
 	// Serve starts the server and begins handling requests
 	Serve() error
 
@@ -53,24 +77,116 @@ type Client interface {
 	// CallTool calls a specific tool on the server with the provided arguments
 	CallTool(ctx context.Context, name string, args interface{}) (*ToolResponse, error)
 
+	// CallToolWithProgress calls a specific tool like CallTool, but additionally
+	// invokes onProgress for every notifications/progress message the server
+	// sends for this call before the final response arrives
+	CallToolWithProgress(ctx context.Context, name string, args interface{}, onProgress func(Progress)) (*ToolResponse, error)
+
+	// CallToolStream calls a specific tool like CallTool, but returns a
+	// channel of ToolEvent that streams progress updates and incremental
+	// content pushed by a streaming tool handler's ToolStream, terminated by
+	// exactly one ToolEventFinal or ToolEventError event before the channel
+	// is closed
+	CallToolStream(ctx context.Context, name string, args interface{}) (<-chan ToolEvent, error)
+
 	// ListPrompts retrieves the list of available prompts from the server
 	ListPrompts(ctx context.Context, cursor *string) (*ListPromptsResponse, error)
 
 	// GetPrompt retrieves a specific prompt from the server
 	GetPrompt(ctx context.Context, name string, args interface{}) (*PromptResponse, error)
 
+	// GetPromptWithProgress calls GetPrompt like normal, but additionally
+	// invokes onProgress for every notifications/progress message the
+	// server sends for this call before the response arrives
+	GetPromptWithProgress(ctx context.Context, name string, args interface{}, onProgress func(Progress)) (*PromptResponse, error)
+
 	// ListResources retrieves the list of available resources from the server
 	ListResources(ctx context.Context, cursor *string) (*ListResourcesResponse, error)
 
 	// ReadResource reads a specific resource from the server
 	ReadResource(ctx context.Context, uri string) (*ResourceResponse, error)
 
+	// ReadResourceWithProgress calls ReadResource like normal, but
+	// additionally invokes onProgress for every notifications/progress
+	// message the server sends for this call before the response arrives
+	ReadResourceWithProgress(ctx context.Context, uri string, onProgress func(Progress)) (*ResourceResponse, error)
+
+	// IterateTools pages through ListTools, following NextCursor until the
+	// server stops returning one, and yields each tool in turn. Iteration
+	// stops early - yielding a final (Tool{}, err) pair - if ctx is
+	// canceled or a ListTools call fails.
+	IterateTools(ctx context.Context) iter.Seq2[Tool, error]
+
+	// IteratePrompts is IterateTools' counterpart for ListPrompts.
+	IteratePrompts(ctx context.Context) iter.Seq2[Prompt, error]
+
+	// IterateResources is IterateTools' counterpart for ListResources.
+	IterateResources(ctx context.Context) iter.Seq2[Resource, error]
+
+	// ListAllTools materializes every page of ListTools into a single
+	// slice, giving up with an error once maxPages pages have been fetched
+	// without exhausting NextCursor. maxPages <= 0 uses a sane default.
+	ListAllTools(ctx context.Context, maxPages int) ([]Tool, error)
+
+	// Subscribe asks the server to send notifications/resources/updated
+	// messages for uri until Unsubscribe is called. Fails with a clear
+	// error if the server didn't advertise the resources.subscribe
+	// capability during Initialize.
+	Subscribe(ctx context.Context, uri string) error
+
+	// Unsubscribe stops notifications/resources/updated messages for uri
+	// that were started with Subscribe.
+	Unsubscribe(ctx context.Context, uri string) error
+
+	// OnResourceUpdated registers handler to be called with the URI carried
+	// by every notifications/resources/updated message the server sends,
+	// e.g. for a resource subscribed to via Subscribe.
+	OnResourceUpdated(handler func(uri string))
+
+	// OnToolsListChanged registers handler to be called whenever the server
+	// sends a notifications/tools/list_changed message, signaling that a
+	// subsequent ListTools call would return a different set of tools.
+	OnToolsListChanged(handler func())
+
+	// OnPromptsListChanged registers handler to be called whenever the
+	// server sends a notifications/prompts/list_changed message, signaling
+	// that a subsequent ListPrompts call would return a different set of
+	// prompts.
+	OnPromptsListChanged(handler func())
+
+	// OnResourcesListChanged registers handler to be called whenever the
+	// server sends a notifications/resources/list_changed message,
+	// signaling that a subsequent ListResources call would return a
+	// different set of resources.
+	OnResourcesListChanged(handler func())
+
+	// Batch issues several requests as a single JSON-RPC batch, e.g. to
+	// fetch tools, prompts, and resources in one round-trip
+	Batch(ctx context.Context, calls []BatchCall) ([]BatchResult, error)
+
 	// Ping sends a ping request to check server connectivity
 	Ping(ctx context.Context) error
 
 	// GetCapabilities returns the server capabilities obtained during initialization
 	GetCapabilities() *ServerCapabilities
 
+	// SetSamplingHandler registers the handler used to service
+	// sampling/createMessage requests from the server, letting the
+	// application plug in its own LLM. Must be called before Initialize:
+	// the sampling capability is only advertised to the server when a
+	// handler is registered.
+	SetSamplingHandler(handler func(ctx context.Context, req SamplingRequest) (*SamplingResponse, error))
+
+	// OnReconnect registers handler to be called before each retry attempt
+	// of an idempotent request (initialize, */list, resources/read, ping),
+	// receiving the 1-based attempt number and the error that triggered it.
+	// Requires a retry policy installed via WithClientOptions.
+	OnReconnect(handler func(attempt int, err error))
+
+	// OnGiveUp registers handler to be called with the final error once an
+	// idempotent request has exhausted its retries.
+	OnGiveUp(handler func(err error))
+
 	// Close closes the client connection
 	Close() error
 }
@@ -0,0 +1,175 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DR1N0/mcp-go/protocol"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// observer holds the tracer and metric instruments shared by every call an
+// interceptor built from it dispatches.
+type observer struct {
+	tracer          trace.Tracer
+	requestTotal    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	inFlight        metric.Int64UpDownCounter
+	errorTotal      metric.Int64Counter
+	transportKind   string
+}
+
+// newObserver builds an observer from opts, falling back to the globally
+// registered TracerProvider/MeterProvider (no-op implementations if none
+// were set) when WithTracerProvider/WithMeterProvider aren't given.
+func newObserver(opts ...Option) *observer {
+	cfg := config{transportKind: "unknown"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tp := cfg.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := cfg.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName)
+
+	o := &observer{
+		tracer:        tp.Tracer(instrumentationName),
+		transportKind: cfg.transportKind,
+	}
+	// Instrument creation only fails on invalid names/units, which we
+	// control; a nil instrument is simply skipped when recording.
+	o.requestTotal, _ = meter.Int64Counter("mcp.request.count",
+		metric.WithDescription("Number of MCP requests dispatched, by method"))
+	o.requestDuration, _ = meter.Float64Histogram("mcp.request.duration",
+		metric.WithDescription("MCP request duration, by method"), metric.WithUnit("s"))
+	o.inFlight, _ = meter.Int64UpDownCounter("mcp.request.in_flight",
+		metric.WithDescription("MCP requests currently in flight, by method"))
+	o.errorTotal, _ = meter.Int64Counter("mcp.request.errors",
+		metric.WithDescription("MCP requests that returned an error, by method and code"))
+	return o
+}
+
+// NewServerInterceptor returns a protocol.ServerInterceptor that starts a
+// span (continuing the caller's trace via the inbound "_meta.traceparent"),
+// records request count/duration/in-flight/error metrics. The span carries
+// the tool name (for tools/call), JSON-RPC request id, transport kind, and
+// error code; the metrics are labeled by method and transport kind, and the
+// error counter also by error code.
+func NewServerInterceptor(opts ...Option) protocol.ServerInterceptor {
+	o := newObserver(opts...)
+	return func(ctx context.Context, method string, params interface{}, next protocol.RequestHandler) (interface{}, error) {
+		ctx = ExtractTraceParent(ctx, params)
+		return o.intercept(ctx, method, params, next, nil)
+	}
+}
+
+// NewClientInterceptor returns a protocol.ClientInterceptor with the same
+// span/metric coverage as NewServerInterceptor, additionally stamping the
+// outgoing request's "_meta.traceparent" so the server can continue this
+// trace.
+func NewClientInterceptor(opts ...Option) protocol.ClientInterceptor {
+	o := newObserver(opts...)
+	return func(ctx context.Context, method string, params interface{}, next protocol.RequestHandler) (interface{}, error) {
+		return o.intercept(ctx, method, params, next, InjectTraceParent)
+	}
+}
+
+// intercept is the shared core of NewServerInterceptor and
+// NewClientInterceptor. transformParams, when non-nil, is applied to params
+// after the span has been started (so it can see the span's own context)
+// and before next is called.
+func (o *observer) intercept(ctx context.Context, method string, params interface{}, next protocol.RequestHandler, transformParams func(context.Context, interface{}) interface{}) (interface{}, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.method", method),
+		attribute.String("mcp.transport", o.transportKind),
+	}
+	if id, ok := protocol.RequestIDFromContext(ctx); ok {
+		attrs = append(attrs, attribute.String("rpc.jsonrpc.request_id", fmt.Sprint(id)))
+	}
+	if method == "tools/call" {
+		if m, ok := params.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok && name != "" {
+				attrs = append(attrs, attribute.String("mcp.tool.name", name))
+			}
+		}
+	}
+
+	ctx, span := o.tracer.Start(ctx, spanName(method, params), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	if transformParams != nil {
+		params = transformParams(ctx, params)
+	}
+
+	metricAttrs := metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.String("transport", o.transportKind),
+	)
+	if o.inFlight != nil {
+		o.inFlight.Add(ctx, 1, metricAttrs)
+		defer o.inFlight.Add(ctx, -1, metricAttrs)
+	}
+
+	start := time.Now()
+	result, err := next(ctx, params)
+
+	if o.requestDuration != nil {
+		o.requestDuration.Record(ctx, time.Since(start).Seconds(), metricAttrs)
+	}
+	if o.requestTotal != nil {
+		o.requestTotal.Add(ctx, 1, metricAttrs)
+	}
+
+	if err != nil {
+		code := errorCode(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attribute.Int("rpc.jsonrpc.error_code", code))
+		if o.errorTotal != nil {
+			o.errorTotal.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("method", method),
+				attribute.String("transport", o.transportKind),
+				attribute.Int("code", code),
+			))
+		}
+	}
+
+	return result, err
+}
+
+// spanName names the span "mcp.tool/<name>" for tools/call dispatches,
+// matching the tool actually being invoked rather than the generic
+// "tools/call" method, and "mcp.<method>" for everything else.
+func spanName(method string, params interface{}) string {
+	if method == "tools/call" {
+		if m, ok := params.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok && name != "" {
+				return "mcp.tool/" + name
+			}
+		}
+	}
+	return "mcp." + method
+}
+
+// errorCode extracts the JSON-RPC error code from err, if it's (or wraps) a
+// *protocol.RemoteError, falling back to -32603 (Internal error) - the code
+// the protocol layer's server side assigns to every handler error it turns
+// into a response.
+func errorCode(err error) int {
+	var remote *protocol.RemoteError
+	if errors.As(err, &remote) {
+		return remote.Code
+	}
+	return -32603
+}
@@ -0,0 +1,61 @@
+// Package observability instruments the MCP client and server dispatch
+// paths with OpenTelemetry tracing and metrics, so a request can be traced
+// and measured end to end regardless of which transport carries it.
+//
+// Install it via the root package's WithTracerProvider / WithMeterProvider
+// server options and WithClientTracerProvider / WithClientMeterProvider
+// client options, which wrap NewServerInterceptor /
+// NewClientInterceptor around every JSON-RPC call using
+// protocol.WithServerInterceptors / protocol.WithClientInterceptors.
+package observability
+
+import (
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package as the source of the spans
+// and metrics it produces, per the OpenTelemetry instrumentation scope
+// convention.
+const instrumentationName = "github.com/DR1N0/mcp-go/observability"
+
+// config holds the settings collected from Options before an observer is
+// built.
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	transportKind  string
+}
+
+// Option configures the observer built by NewServerInterceptor or
+// NewClientInterceptor.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider used to start a span for every
+// dispatched request. Defaults to the globally registered TracerProvider (a
+// no-op tracer if none was set via otel.SetTracerProvider).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record request count,
+// duration, in-flight, and per-error-code metrics for every dispatched
+// request. Defaults to the globally registered MeterProvider (a no-op
+// meter if none was set via otel.SetMeterProvider).
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithTransportKind labels every span and metric this observer produces
+// with kind (e.g. "stdio", "sse", "streamable-http", "grpc"), so a
+// multi-transport deployment can break dashboards out by transport.
+// Defaults to "unknown".
+func WithTransportKind(kind string) Option {
+	return func(c *config) {
+		c.transportKind = kind
+	}
+}
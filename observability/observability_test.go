@@ -0,0 +1,78 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DR1N0/mcp-go/protocol"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanName_ToolCallUsesToolName(t *testing.T) {
+	got := spanName("tools/call", map[string]interface{}{"name": "search", "arguments": nil})
+	if got != "mcp.tool/search" {
+		t.Errorf("expected mcp.tool/search, got %q", got)
+	}
+}
+
+func TestSpanName_OtherMethodsUseMethod(t *testing.T) {
+	got := spanName("resources/read", map[string]interface{}{"uri": "file:///x"})
+	if got != "mcp.resources/read" {
+		t.Errorf("expected mcp.resources/read, got %q", got)
+	}
+}
+
+func TestErrorCode_RemoteErrorPropagates(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &protocol.RemoteError{Code: -32601, Message: "Method not found"})
+	if got := errorCode(err); got != -32601 {
+		t.Errorf("expected -32601, got %d", got)
+	}
+}
+
+func TestErrorCode_UnstructuredErrorFallsBackToInternal(t *testing.T) {
+	if got := errorCode(errors.New("boom")); got != -32603 {
+		t.Errorf("expected -32603, got %d", got)
+	}
+}
+
+func TestTraceParent_InjectThenExtractRoundTrips(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	params := InjectTraceParent(ctx, map[string]interface{}{"name": "search"})
+
+	extracted := ExtractTraceParent(context.Background(), params)
+	got := trace.SpanContextFromContext(extracted)
+
+	if got.TraceID() != sc.TraceID() {
+		t.Errorf("trace id mismatch: got %s, want %s", got.TraceID(), sc.TraceID())
+	}
+	if got.SpanID() != sc.SpanID() {
+		t.Errorf("span id mismatch: got %s, want %s", got.SpanID(), sc.SpanID())
+	}
+	if !got.IsSampled() {
+		t.Error("expected extracted span context to be sampled")
+	}
+	if !got.IsRemote() {
+		t.Error("expected extracted span context to be marked remote")
+	}
+}
+
+func TestTraceParent_InjectSkipsNonMapParams(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	if got := InjectTraceParent(ctx, "not-a-map"); got != "not-a-map" {
+		t.Errorf("expected params to pass through unchanged, got %v", got)
+	}
+}
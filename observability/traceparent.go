@@ -0,0 +1,111 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	metaField        = "_meta"
+	traceparentField = "traceparent"
+)
+
+// InjectTraceParent stamps the W3C traceparent for the span active in ctx
+// into params's "_meta.traceparent" field, so a server on the other end of
+// the wire can continue the same trace. params is returned unchanged if it
+// isn't a map[string]interface{} (the shape every client.go call builds) or
+// ctx carries no valid span.
+func InjectTraceParent(ctx context.Context, params interface{}) interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return params
+	}
+
+	m, ok := params.(map[string]interface{})
+	if !ok {
+		return params
+	}
+
+	meta, ok := m[metaField].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+	}
+	meta[traceparentField] = formatTraceParent(sc)
+	m[metaField] = meta
+	return m
+}
+
+// ExtractTraceParent parses a W3C traceparent out of params's
+// "_meta.traceparent" field, if present, returning ctx augmented with the
+// resulting remote SpanContext as the parent for a server-side span. ctx is
+// returned unchanged if params carries no valid traceparent.
+func ExtractTraceParent(ctx context.Context, params interface{}) context.Context {
+	m, ok := params.(map[string]interface{})
+	if !ok {
+		return ctx
+	}
+
+	meta, ok := m[metaField].(map[string]interface{})
+	if !ok {
+		return ctx
+	}
+
+	raw, ok := meta[traceparentField].(string)
+	if !ok {
+		return ctx
+	}
+
+	sc, ok := parseTraceParent(raw)
+	if !ok {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// formatTraceParent renders sc as a "00-<trace-id>-<span-id>-<flags>"
+// traceparent header value, per the W3C Trace Context spec.
+func formatTraceParent(sc trace.SpanContext) string {
+	flags := "00"
+	if sc.IsSampled() {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), flags)
+}
+
+// parseTraceParent parses a "00-<trace-id>-<span-id>-<flags>" traceparent
+// header value into a remote SpanContext. Only version "00" is supported,
+// matching the current W3C Trace Context spec.
+func parseTraceParent(raw string) (trace.SpanContext, bool) {
+	parts := strings.Split(raw, "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return trace.SpanContext{}, false
+	}
+
+	traceID, err := trace.TraceIDFromHex(parts[1])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	spanID, err := trace.SpanIDFromHex(parts[2])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(flags),
+		Remote:     true,
+	})
+	if !sc.IsValid() {
+		return trace.SpanContext{}, false
+	}
+	return sc, true
+}
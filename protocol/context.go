@@ -0,0 +1,22 @@
+package protocol
+
+import "context"
+
+// requestIDContextKey is an unexported type so values stashed under it can't
+// collide with keys set by other packages.
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the JSON-RPC request
+// id, so client and server interceptors deep in a Request or dispatch call
+// chain (e.g. for tracing/metrics) can attribute their work to the right id
+// without threading it through every interceptor signature.
+func ContextWithRequestID(ctx context.Context, id interface{}) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the JSON-RPC request id stashed by
+// ContextWithRequestID, if any.
+func RequestIDFromContext(ctx context.Context) (interface{}, bool) {
+	id := ctx.Value(requestIDContextKey{})
+	return id, id != nil
+}
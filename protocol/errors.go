@@ -0,0 +1,16 @@
+package protocol
+
+import "fmt"
+
+// RemoteError wraps a JSON-RPC error returned by the peer in response to a
+// Request, preserving its numeric code so callers - retry logic, circuit
+// breakers, observability instrumentation - can branch on it instead of
+// parsing it back out of Error()'s formatted string.
+type RemoteError struct {
+	Code    int
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
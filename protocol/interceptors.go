@@ -0,0 +1,61 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// LoggingInterceptor returns a ServerInterceptor that logs the method,
+// duration, and error (if any) of every dispatched request to logger
+func LoggingInterceptor(logger *log.Logger) ServerInterceptor {
+	return func(ctx context.Context, method string, params interface{}, next RequestHandler) (interface{}, error) {
+		start := time.Now()
+		result, err := next(ctx, params)
+		logger.Printf("method=%s duration=%s error=%v", method, time.Since(start), err)
+		return result, err
+	}
+}
+
+// TimeoutInterceptor returns a ServerInterceptor that bounds each dispatched
+// request's handler to timeout, returning ctx.Err() if the handler doesn't
+// finish in time
+func TimeoutInterceptor(timeout time.Duration) ServerInterceptor {
+	return func(ctx context.Context, method string, params interface{}, next RequestHandler) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		type outcome struct {
+			result interface{}
+			err    error
+		}
+		done := make(chan outcome, 1)
+
+		go func() {
+			result, err := next(ctx, params)
+			done <- outcome{result, err}
+		}()
+
+		select {
+		case o := <-done:
+			return o.result, o.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// RecoveryInterceptor returns a ServerInterceptor that recovers from panics
+// in the wrapped handler, turning them into a JSON-RPC -32603 internal error
+// instead of taking down the process
+func RecoveryInterceptor() ServerInterceptor {
+	return func(ctx context.Context, method string, params interface{}, next RequestHandler) (result interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic in handler for %s: %v", method, r)
+			}
+		}()
+		return next(ctx, params)
+	}
+}
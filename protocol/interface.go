@@ -14,6 +14,10 @@ type Protocol interface {
 	// Request sends a request and waits for a response
 	Request(ctx context.Context, method string, params interface{}) (interface{}, error)
 
+	// Batch sends several requests as a single JSON-RPC batch and waits for
+	// all of their responses, preserving the order of calls.
+	Batch(ctx context.Context, calls []BatchCall) ([]BatchResult, error)
+
 	// Notification sends a notification (no response expected)
 	Notification(method string, params interface{}) error
 
@@ -23,6 +27,22 @@ type Protocol interface {
 	// SetNotificationHandler registers a handler for incoming notifications
 	SetNotificationHandler(method string, handler NotificationHandler)
 
+	// RegisterProgressHandler routes notifications/progress messages carrying
+	// the given progress token to cb until UnregisterProgressHandler is called
+	RegisterProgressHandler(token interface{}, cb ProgressHandler)
+
+	// UnregisterProgressHandler stops routing progress notifications for token
+	UnregisterProgressHandler(token interface{})
+
+	// RegisterPartialHandler routes notifications/tools/partial messages
+	// carrying the given progress token to cb until UnregisterPartialHandler
+	// is called
+	RegisterPartialHandler(token interface{}, cb PartialHandler)
+
+	// UnregisterPartialHandler stops routing partial-result notifications
+	// for token
+	UnregisterPartialHandler(token interface{})
+
 	// Close shuts down the protocol
 	Close() error
 }
@@ -32,3 +52,50 @@ type RequestHandler func(ctx context.Context, params interface{}) (interface{},
 
 // NotificationHandler handles incoming JSON-RPC notifications
 type NotificationHandler func(params interface{}) error
+
+// BatchCall describes a single request to issue as part of a Batch call
+type BatchCall struct {
+	Method string
+	Params interface{}
+}
+
+// BatchResult is the outcome of one BatchCall, in the same order as the
+// calls passed to Batch
+type BatchResult struct {
+	Result interface{}
+	Error  error
+}
+
+// ProgressHandler receives progress updates for a single in-flight request,
+// identified by the progress token it was registered under. message is the
+// notification's "message" field, if the sender included one (e.g. a
+// ToolStream), and is empty otherwise.
+type ProgressHandler func(progress float64, total *float64, message string)
+
+// PartialHandler receives incremental content for a single in-flight
+// tools/call, identified by the progress token it was registered under.
+// content is the raw decoded "content" field of a notifications/tools/partial
+// message - typically a []interface{} of content-object maps - left for the
+// caller to re-marshal into its own typed representation.
+type PartialHandler func(content interface{})
+
+// ClientInterceptor wraps an outgoing Request call, letting cross-cutting
+// concerns (logging, tracing, auth, retries, rate limiting) run around it
+// without forking the transport or duplicating code in every caller. Call
+// next to continue the chain, or to perform the actual send on the
+// innermost link.
+type ClientInterceptor func(ctx context.Context, method string, params interface{}, next RequestHandler) (interface{}, error)
+
+// ClientNotificationInterceptor is the notification-shaped analogue of
+// ClientInterceptor, wrapping an outgoing Notification call
+type ClientNotificationInterceptor func(method string, params interface{}, next NotificationHandler) error
+
+// ServerInterceptor wraps the dispatch of an incoming request to its
+// registered RequestHandler. Call next to continue the chain, or to invoke
+// the handler on the innermost link.
+type ServerInterceptor func(ctx context.Context, method string, params interface{}, next RequestHandler) (interface{}, error)
+
+// ServerNotificationInterceptor is the notification-shaped analogue of
+// ServerInterceptor, wrapping the dispatch of an incoming notification to
+// its registered NotificationHandler
+type ServerNotificationInterceptor func(method string, params interface{}, next NotificationHandler) error
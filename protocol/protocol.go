@@ -12,21 +12,242 @@ import (
 
 // jsonRpcProtocol implements the Protocol interface
 type jsonRpcProtocol struct {
-	transport            types.Transport
-	requestHandlers      map[string]RequestHandler
-	notificationHandlers map[string]NotificationHandler
-	pendingRequests      map[interface{}]chan interface{}
-	requestID            atomic.Int64
-	mu                   sync.RWMutex
+	transport               types.Transport
+	requestHandlers         map[string]RequestHandler
+	notificationHandlers    map[string]NotificationHandler
+	pendingRequests         map[interface{}]chan interface{}
+	canceledRequests        map[interface{}]bool
+	handling                map[interface{}]context.CancelFunc
+	progressHandlers        map[interface{}]ProgressHandler
+	partialHandlers         map[interface{}]PartialHandler
+	clientInterceptors      []ClientInterceptor
+	clientNotifInterceptors []ClientNotificationInterceptor
+	serverInterceptors      []ServerInterceptor
+	serverNotifInterceptors []ServerNotificationInterceptor
+	requestID               atomic.Int64
+	mu                      sync.RWMutex
+	handlingMu              sync.Mutex
+	progressMu              sync.RWMutex
+	partialMu               sync.RWMutex
+}
+
+// Option configures a jsonRpcProtocol created by NewProtocol
+type Option func(*jsonRpcProtocol)
+
+// WithClientInterceptors chains interceptors, in order, around every
+// outgoing Request call
+func WithClientInterceptors(interceptors ...ClientInterceptor) Option {
+	return func(p *jsonRpcProtocol) {
+		p.clientInterceptors = append(p.clientInterceptors, interceptors...)
+	}
+}
+
+// WithClientNotificationInterceptors chains interceptors, in order, around
+// every outgoing Notification call
+func WithClientNotificationInterceptors(interceptors ...ClientNotificationInterceptor) Option {
+	return func(p *jsonRpcProtocol) {
+		p.clientNotifInterceptors = append(p.clientNotifInterceptors, interceptors...)
+	}
+}
+
+// WithServerInterceptors chains interceptors, in order, around the dispatch
+// of every incoming request to its registered handler
+func WithServerInterceptors(interceptors ...ServerInterceptor) Option {
+	return func(p *jsonRpcProtocol) {
+		p.serverInterceptors = append(p.serverInterceptors, interceptors...)
+	}
+}
+
+// WithServerNotificationInterceptors chains interceptors, in order, around
+// the dispatch of every incoming notification to its registered handler
+func WithServerNotificationInterceptors(interceptors ...ServerNotificationInterceptor) Option {
+	return func(p *jsonRpcProtocol) {
+		p.serverNotifInterceptors = append(p.serverNotifInterceptors, interceptors...)
+	}
 }
 
 // NewProtocol creates a new JSON-RPC 2.0 protocol handler
-func NewProtocol() Protocol {
-	return &jsonRpcProtocol{
+func NewProtocol(opts ...Option) Protocol {
+	p := &jsonRpcProtocol{
 		requestHandlers:      make(map[string]RequestHandler),
 		notificationHandlers: make(map[string]NotificationHandler),
 		pendingRequests:      make(map[interface{}]chan interface{}),
+		canceledRequests:     make(map[interface{}]bool),
+		handling:             make(map[interface{}]context.CancelFunc),
+		progressHandlers:     make(map[interface{}]ProgressHandler),
+		partialHandlers:      make(map[interface{}]PartialHandler),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Built-in handlers for peer-initiated cancellation and progress routing
+	p.notificationHandlers["notifications/cancelled"] = p.handleCancelledNotification
+	p.notificationHandlers["notifications/progress"] = p.handleProgressNotification
+	p.notificationHandlers["notifications/tools/partial"] = p.handlePartialNotification
+
+	return p
+}
+
+// chainRequest composes interceptors, in order, around final so that
+// interceptors[0] runs outermost
+func chainRequest(interceptors []ServerInterceptor, method string, final RequestHandler) RequestHandler {
+	chained := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := chained
+		chained = func(ctx context.Context, params interface{}) (interface{}, error) {
+			return interceptor(ctx, method, params, next)
+		}
+	}
+	return chained
+}
+
+// chainClientRequest is chainRequest's counterpart for the client-side
+// ClientInterceptor chain
+func chainClientRequest(interceptors []ClientInterceptor, method string, final RequestHandler) RequestHandler {
+	chained := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := chained
+		chained = func(ctx context.Context, params interface{}) (interface{}, error) {
+			return interceptor(ctx, method, params, next)
+		}
+	}
+	return chained
+}
+
+// chainNotification composes notification interceptors, in order, around
+// final so that interceptors[0] runs outermost
+func chainNotification(interceptors []ServerNotificationInterceptor, method string, final NotificationHandler) NotificationHandler {
+	chained := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := chained
+		chained = func(params interface{}) error {
+			return interceptor(method, params, next)
+		}
+	}
+	return chained
+}
+
+// chainClientNotification is chainNotification's counterpart for the
+// client-side ClientNotificationInterceptor chain
+func chainClientNotification(interceptors []ClientNotificationInterceptor, method string, final NotificationHandler) NotificationHandler {
+	chained := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := chained
+		chained = func(params interface{}) error {
+			return interceptor(method, params, next)
+		}
+	}
+	return chained
+}
+
+// handleProgressNotification routes a notifications/progress message to the
+// callback registered for its progress token, if any
+func (p *jsonRpcProtocol) handleProgressNotification(params interface{}) error {
+	paramsMap, ok := params.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid progress notification params")
+	}
+
+	token := paramsMap["progressToken"]
+
+	p.progressMu.RLock()
+	cb, ok := p.progressHandlers[token]
+	p.progressMu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	progress, _ := paramsMap["progress"].(float64)
+	var total *float64
+	if t, ok := paramsMap["total"].(float64); ok {
+		total = &t
+	}
+	message, _ := paramsMap["message"].(string)
+
+	cb(progress, total, message)
+	return nil
+}
+
+// RegisterProgressHandler routes notifications/progress messages carrying
+// the given progress token to cb until UnregisterProgressHandler is called
+func (p *jsonRpcProtocol) RegisterProgressHandler(token interface{}, cb ProgressHandler) {
+	p.progressMu.Lock()
+	defer p.progressMu.Unlock()
+	p.progressHandlers[token] = cb
+}
+
+// UnregisterProgressHandler stops routing progress notifications for token
+func (p *jsonRpcProtocol) UnregisterProgressHandler(token interface{}) {
+	p.progressMu.Lock()
+	defer p.progressMu.Unlock()
+	delete(p.progressHandlers, token)
+}
+
+// handlePartialNotification routes a notifications/tools/partial message to
+// the callback registered for its progress token, if any
+func (p *jsonRpcProtocol) handlePartialNotification(params interface{}) error {
+	paramsMap, ok := params.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid partial notification params")
+	}
+
+	token := paramsMap["progressToken"]
+
+	p.partialMu.RLock()
+	cb, ok := p.partialHandlers[token]
+	p.partialMu.RUnlock()
+
+	if !ok {
+		return nil
 	}
+
+	cb(paramsMap["content"])
+	return nil
+}
+
+// RegisterPartialHandler routes notifications/tools/partial messages
+// carrying the given progress token to cb until UnregisterPartialHandler is
+// called
+func (p *jsonRpcProtocol) RegisterPartialHandler(token interface{}, cb PartialHandler) {
+	p.partialMu.Lock()
+	defer p.partialMu.Unlock()
+	p.partialHandlers[token] = cb
+}
+
+// UnregisterPartialHandler stops routing partial-result notifications for
+// token
+func (p *jsonRpcProtocol) UnregisterPartialHandler(token interface{}) {
+	p.partialMu.Lock()
+	defer p.partialMu.Unlock()
+	delete(p.partialHandlers, token)
+}
+
+// handleCancelledNotification looks up the in-flight request named by the
+// notification's requestId and cancels its handler context, if still running.
+func (p *jsonRpcProtocol) handleCancelledNotification(params interface{}) error {
+	paramsMap, ok := params.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid cancelled notification params")
+	}
+
+	requestID := paramsMap["requestId"]
+
+	p.handlingMu.Lock()
+	cancel, ok := p.handling[requestID]
+	p.handlingMu.Unlock()
+
+	if ok {
+		cancel()
+	}
+
+	return nil
 }
 
 // Connect attaches the protocol to a transport
@@ -67,6 +288,20 @@ func (p *jsonRpcProtocol) handleRequest(ctx context.Context, msg *types.BaseJSON
 	handler, ok := p.requestHandlers[msg.Method]
 	p.mu.RUnlock()
 
+	// Track a cancel func for this request so a later notifications/cancelled
+	// can stop the in-flight handler promptly.
+	ctx, cancel := context.WithCancel(ctx)
+	ctx = ContextWithRequestID(ctx, msg.ID)
+	p.handlingMu.Lock()
+	p.handling[msg.ID] = cancel
+	p.handlingMu.Unlock()
+	defer func() {
+		p.handlingMu.Lock()
+		delete(p.handling, msg.ID)
+		p.handlingMu.Unlock()
+		cancel()
+	}()
+
 	var response types.BaseJSONRPCMessage
 	response.JSONRPC = "2.0"
 	response.ID = msg.ID
@@ -92,8 +327,8 @@ func (p *jsonRpcProtocol) handleRequest(ctx context.Context, msg *types.BaseJSON
 		}
 
 		if response.Error == nil {
-			// Call the handler
-			result, err := handler(ctx, params)
+			// Call the handler, routed through any registered server interceptors
+			result, err := chainRequest(p.serverInterceptors, msg.Method, handler)(ctx, params)
 			if err != nil {
 				response.Error = &types.RPCError{
 					Code:    -32603,
@@ -130,7 +365,16 @@ func (p *jsonRpcProtocol) handleResponse(msg *types.BaseJSONRPCMessage) {
 	p.mu.RUnlock()
 
 	if !ok {
-		fmt.Printf("Received response for unknown request ID: %v\n", msg.ID)
+		// A response for a request we already gave up on (e.g. the caller's
+		// context was canceled) is expected and should be dropped quietly.
+		p.mu.Lock()
+		canceled := p.canceledRequests[msg.ID]
+		delete(p.canceledRequests, msg.ID)
+		p.mu.Unlock()
+
+		if !canceled {
+			fmt.Printf("Received response for unknown request ID: %v\n", msg.ID)
+		}
 		return
 	}
 
@@ -162,15 +406,28 @@ func (p *jsonRpcProtocol) handleNotification(msg *types.BaseJSONRPCMessage) {
 		}
 	}
 
-	// Call the handler (ignore errors for notifications)
-	_ = handler(params)
+	// Call the handler, routed through any registered server interceptors
+	// (errors are still ignored for notifications, per the JSON-RPC spec)
+	_ = chainNotification(p.serverNotifInterceptors, msg.Method, handler)(params)
 }
 
-// Request sends a request and waits for a response
+// Request sends a request and waits for a response, routed through any
+// registered client interceptors
 func (p *jsonRpcProtocol) Request(ctx context.Context, method string, params interface{}) (interface{}, error) {
-	// Generate a unique request ID
+	// Generated up front, rather than inside doRequest, so interceptors can
+	// read it via RequestIDFromContext before the send happens
 	id := p.requestID.Add(1)
+	ctx = ContextWithRequestID(ctx, id)
+
+	final := func(ctx context.Context, params interface{}) (interface{}, error) {
+		return p.doRequest(ctx, id, method, params)
+	}
+	return chainClientRequest(p.clientInterceptors, method, final)(ctx, params)
+}
 
+// doRequest is the terminal step of the client interceptor chain: it sends
+// the request and waits for a response
+func (p *jsonRpcProtocol) doRequest(ctx context.Context, id int64, method string, params interface{}) (interface{}, error) {
 	// Serialize params
 	var paramsBytes json.RawMessage
 	if params != nil {
@@ -219,17 +476,125 @@ func (p *jsonRpcProtocol) Request(ctx context.Context, method string, params int
 
 		// Check for errors
 		if responseMsg.Error != nil {
-			return nil, fmt.Errorf("RPC error %d: %s", responseMsg.Error.Code, responseMsg.Error.Message)
+			return nil, &RemoteError{Code: responseMsg.Error.Code, Message: responseMsg.Error.Message}
 		}
 
 		return responseMsg.Result, nil
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		p.mu.Lock()
+		p.canceledRequests[id] = true
+		p.mu.Unlock()
+
+		// Tell the peer to stop working on a request we're no longer waiting on.
+		cancelErr := ctx.Err()
+		reason := cancelErr.Error()
+		if notifyErr := p.Notification("notifications/cancelled", types.CancelledParams{
+			RequestID: id,
+			Reason:    reason,
+		}); notifyErr != nil {
+			fmt.Printf("Error sending cancellation notification: %v\n", notifyErr)
+		}
+
+		return nil, cancelErr
 	}
 }
 
-// Notification sends a notification (no response expected)
+// Batch sends several requests as a single JSON-RPC batch, using the
+// transport's SendBatch when available and falling back to issuing the
+// calls as individual messages otherwise.
+func (p *jsonRpcProtocol) Batch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	msgs := make([]*types.BaseJSONRPCMessage, len(calls))
+	ids := make([]int64, len(calls))
+	responseChans := make([]chan interface{}, len(calls))
+
+	for i, call := range calls {
+		id := p.requestID.Add(1)
+		ids[i] = id
+
+		var paramsBytes json.RawMessage
+		if call.Params != nil {
+			var err error
+			paramsBytes, err = json.Marshal(call.Params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal params for %s: %w", call.Method, err)
+			}
+		}
+
+		msgs[i] = &types.BaseJSONRPCMessage{
+			JSONRPC: "2.0",
+			ID:      id,
+			Method:  call.Method,
+			Params:  paramsBytes,
+		}
+
+		responseChan := make(chan interface{}, 1)
+		responseChans[i] = responseChan
+
+		p.mu.Lock()
+		p.pendingRequests[id] = responseChan
+		p.mu.Unlock()
+	}
+
+	defer func() {
+		p.mu.Lock()
+		for i, id := range ids {
+			delete(p.pendingRequests, id)
+			close(responseChans[i])
+		}
+		p.mu.Unlock()
+	}()
+
+	// Prefer a single wire-level batch when the transport supports it
+	if batchTransport, ok := p.transport.(types.BatchTransport); ok {
+		if err := batchTransport.SendBatch(ctx, msgs); err != nil {
+			return nil, fmt.Errorf("failed to send batch: %w", err)
+		}
+	} else {
+		for _, msg := range msgs {
+			if err := p.transport.Send(ctx, msg); err != nil {
+				return nil, fmt.Errorf("failed to send batch message %s: %w", msg.Method, err)
+			}
+		}
+	}
+
+	results := make([]BatchResult, len(calls))
+	for i := range calls {
+		select {
+		case response := <-responseChans[i]:
+			responseMsg, ok := response.(*types.BaseJSONRPCMessage)
+			if !ok {
+				results[i] = BatchResult{Error: fmt.Errorf("invalid response type")}
+				continue
+			}
+			if responseMsg.Error != nil {
+				results[i] = BatchResult{Error: fmt.Errorf("RPC error %d: %s", responseMsg.Error.Code, responseMsg.Error.Message)}
+				continue
+			}
+			results[i] = BatchResult{Result: responseMsg.Result}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return results, nil
+}
+
+// Notification sends a notification (no response expected), routed through
+// any registered client notification interceptors
 func (p *jsonRpcProtocol) Notification(method string, params interface{}) error {
+	final := func(params interface{}) error {
+		return p.doNotification(method, params)
+	}
+	return chainClientNotification(p.clientNotifInterceptors, method, final)(params)
+}
+
+// doNotification is the terminal step of the client notification
+// interceptor chain: it serializes and sends the notification
+func (p *jsonRpcProtocol) doNotification(method string, params interface{}) error {
 	// Serialize params
 	var paramsBytes json.RawMessage
 	if params != nil {
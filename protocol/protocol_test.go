@@ -3,6 +3,7 @@ package protocol
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -308,3 +309,95 @@ func TestProtocol_MethodNotFound(t *testing.T) {
 		t.Error("Expected method not found error")
 	}
 }
+
+func TestProtocol_CancelSendsNotification(t *testing.T) {
+	mock := transport.NewMock()
+	proto := NewProtocol()
+
+	if err := proto.Connect(mock); err != nil {
+		t.Fatalf("Failed to connect protocol: %v", err)
+	}
+	defer proto.Close()
+
+	// Don't simulate any response - the request should time out and the
+	// protocol should tell the peer to stop working on it.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := proto.Request(ctx, "test/method", nil)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded, got: %v", err)
+	}
+
+	msgs := mock.GetSentMessages()
+	var cancelMsg *types.BaseJSONRPCMessage
+	for _, msg := range msgs {
+		if msg.Method == "notifications/cancelled" {
+			cancelMsg = msg
+			break
+		}
+	}
+	if cancelMsg == nil {
+		t.Fatal("Expected a notifications/cancelled message")
+	}
+	if cancelMsg.ID != nil {
+		t.Error("Cancellation should be sent as a notification, not a request")
+	}
+
+	var params types.CancelledParams
+	if err := json.Unmarshal(cancelMsg.Params, &params); err != nil {
+		t.Fatalf("Failed to unmarshal cancellation params: %v", err)
+	}
+	// requestId round-trips through JSON as a float64; compare numerically
+	// rather than against msgs[0].ID's original int64 type.
+	if fmt.Sprintf("%v", params.RequestID) != fmt.Sprintf("%v", msgs[0].ID) {
+		t.Errorf("Expected cancelled requestId %v, got %v", msgs[0].ID, params.RequestID)
+	}
+}
+
+func TestProtocol_CancelledNotificationCancelsHandler(t *testing.T) {
+	mock := transport.NewMock()
+	proto := NewProtocol()
+
+	if err := proto.Connect(mock); err != nil {
+		t.Fatalf("Failed to connect protocol: %v", err)
+	}
+	defer proto.Close()
+
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+	proto.SetRequestHandler("test/slow", func(ctx context.Context, params interface{}) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	})
+
+	request := &types.BaseJSONRPCMessage{
+		JSONRPC: "2.0",
+		ID:      float64(1),
+		Method:  "test/slow",
+	}
+	// The mock delivers a message by calling the handler in the caller's
+	// goroutine, so deliver the request from its own goroutine and wait for
+	// the handler to be running before the cancellation arrives.
+	go mock.SimulateReceive(context.Background(), request)
+	select {
+	case <-started:
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected handler to start")
+	}
+
+	cancelNotif := &types.BaseJSONRPCMessage{
+		JSONRPC: "2.0",
+		Method:  "notifications/cancelled",
+		Params:  json.RawMessage(`{"requestId": 1, "reason": "client disconnected"}`),
+	}
+	mock.SimulateReceive(context.Background(), cancelNotif)
+
+	select {
+	case <-canceled:
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected handler's context to be canceled")
+	}
+}
@@ -0,0 +1,177 @@
+// Package replay records live JSON-RPC traffic between an MCP client and
+// server, and replays a recorded session against a (possibly different)
+// server implementation as a regression fixture - an approach borrowed
+// from gopls' LSP-log replay tool.
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/DR1N0/mcp-go/types"
+)
+
+// Direction classifies one recorded JSON-RPC frame by who sent it and what
+// kind of frame it is, mirroring gopls' Direction enum for LSP logs.
+type Direction string
+
+const (
+	// ClientRequest is a request sent by the client (has Method and ID).
+	ClientRequest Direction = "client_request"
+	// ClientNotification is a notification sent by the client (has Method, no ID).
+	ClientNotification Direction = "client_notification"
+	// ClientResponse is the client's response to a server-initiated request
+	// (e.g. sampling/createMessage).
+	ClientResponse Direction = "client_response"
+	// ServerRequest is a request sent by the server to the client.
+	ServerRequest Direction = "server_request"
+	// ServerResponse is the server's response to a client request.
+	ServerResponse Direction = "server_response"
+	// ServerNotification is a notification sent by the server.
+	ServerNotification Direction = "server_notification"
+	// Error records a transport-level error observed while recording,
+	// rather than a JSON-RPC frame.
+	Error Direction = "error"
+)
+
+// Entry is one line of a recorded session log.
+type Entry struct {
+	Direction Direction                 `json:"direction"`
+	Timestamp time.Time                 `json:"timestamp"`
+	Message   *types.BaseJSONRPCMessage `json:"message,omitempty"`
+	Err       string                    `json:"error,omitempty"`
+}
+
+// classify reports the Direction of msg, given whether it was sent (server
+// to client) or received (client to server) by the wrapped transport.
+func classify(msg *types.BaseJSONRPCMessage, sent bool) Direction {
+	switch {
+	case msg.Result != nil || msg.Error != nil:
+		if sent {
+			return ServerResponse
+		}
+		return ClientResponse
+	case msg.Method != "" && msg.ID != nil:
+		if sent {
+			return ServerRequest
+		}
+		return ClientRequest
+	default:
+		if sent {
+			return ServerNotification
+		}
+		return ClientNotification
+	}
+}
+
+// RecordingTransport wraps a server-side types.Transport, writing every
+// inbound (client to server) and outbound (server to client) JSON-RPC frame
+// to w as a newline-delimited JSON Entry, in the order it's observed.
+type RecordingTransport struct {
+	underlying types.Transport
+	now        func() time.Time
+
+	mu sync.Mutex
+	w  io.Writer
+
+	userMessageHandler func(ctx context.Context, msg *types.BaseJSONRPCMessage)
+	userErrorHandler   func(error)
+}
+
+// NewRecordingTransport returns a RecordingTransport that proxies underlying,
+// logging every frame it sends or receives to w.
+func NewRecordingTransport(underlying types.Transport, w io.Writer) *RecordingTransport {
+	rt := &RecordingTransport{
+		underlying: underlying,
+		now:        time.Now,
+		w:          w,
+	}
+	underlying.SetMessageHandler(rt.handleMessage)
+	underlying.SetErrorHandler(rt.handleError)
+	return rt
+}
+
+func (rt *RecordingTransport) writeEntry(e Entry) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	data, err := json.Marshal(e)
+	if err != nil {
+		// Shouldn't happen for well-formed JSON-RPC frames; drop silently
+		// rather than corrupting the log with a partial line.
+		return
+	}
+	rt.w.Write(append(data, '\n'))
+}
+
+// handleMessage is installed as the underlying transport's message handler,
+// so every inbound (client to server) frame is logged before being passed
+// on to whatever handler the caller installs via SetMessageHandler.
+func (rt *RecordingTransport) handleMessage(ctx context.Context, msg *types.BaseJSONRPCMessage) {
+	rt.writeEntry(Entry{Direction: classify(msg, false), Timestamp: rt.now(), Message: msg})
+	if rt.userMessageHandler != nil {
+		rt.userMessageHandler(ctx, msg)
+	}
+}
+
+func (rt *RecordingTransport) handleError(err error) {
+	rt.writeEntry(Entry{Direction: Error, Timestamp: rt.now(), Err: err.Error()})
+	if rt.userErrorHandler != nil {
+		rt.userErrorHandler(err)
+	}
+}
+
+// Start starts the underlying transport.
+func (rt *RecordingTransport) Start(ctx context.Context) error {
+	return rt.underlying.Start(ctx)
+}
+
+// Send logs msg as an outbound (server to client) frame, then sends it
+// through the underlying transport.
+func (rt *RecordingTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMessage) error {
+	rt.writeEntry(Entry{Direction: classify(msg, true), Timestamp: rt.now(), Message: msg})
+	return rt.underlying.Send(ctx, msg)
+}
+
+// Close closes the underlying transport.
+func (rt *RecordingTransport) Close() error {
+	return rt.underlying.Close()
+}
+
+// SetMessageHandler registers handler to be called, after logging, with
+// every inbound frame.
+func (rt *RecordingTransport) SetMessageHandler(handler func(ctx context.Context, msg *types.BaseJSONRPCMessage)) {
+	rt.userMessageHandler = handler
+}
+
+// SetErrorHandler registers handler to be called, after logging, with every
+// transport error.
+func (rt *RecordingTransport) SetErrorHandler(handler func(error)) {
+	rt.userErrorHandler = handler
+}
+
+// SetCloseHandler delegates to the underlying transport.
+func (rt *RecordingTransport) SetCloseHandler(handler func()) {
+	rt.underlying.SetCloseHandler(handler)
+}
+
+// ReadLog parses a newline-delimited JSON session log, as written by
+// RecordingTransport, into its entries in recorded order.
+func ReadLog(r io.Reader) ([]Entry, error) {
+	dec := json.NewDecoder(r)
+	var entries []Entry
+	for {
+		var e Entry
+		if err := dec.Decode(&e); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse session log entry %d: %w", len(entries), err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
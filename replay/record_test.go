@@ -0,0 +1,77 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DR1N0/mcp-go/transport"
+	"github.com/DR1N0/mcp-go/types"
+)
+
+func TestRecordingTransport_LogsInboundAndOutbound(t *testing.T) {
+	mock := transport.NewMock()
+	var buf bytes.Buffer
+	rt := NewRecordingTransport(mock, &buf)
+
+	var received *types.BaseJSONRPCMessage
+	rt.SetMessageHandler(func(ctx context.Context, msg *types.BaseJSONRPCMessage) {
+		received = msg
+	})
+
+	req := &types.BaseJSONRPCMessage{JSONRPC: "2.0", ID: int64(1), Method: "tools/list"}
+	mock.SimulateReceive(context.Background(), req)
+	if received != req {
+		t.Fatalf("expected the wrapped handler to see the inbound message")
+	}
+
+	resp := &types.BaseJSONRPCMessage{JSONRPC: "2.0", ID: int64(1), Result: json.RawMessage(`{"tools":[]}`)}
+	if err := rt.Send(context.Background(), resp); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if got := mock.GetSentMessages(); len(got) != 1 || got[0] != resp {
+		t.Fatalf("expected the underlying transport to receive the sent message, got %v", got)
+	}
+
+	entries, err := ReadLog(&buf)
+	if err != nil {
+		t.Fatalf("ReadLog failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	if entries[0].Direction != ClientRequest {
+		t.Fatalf("expected first entry to be ClientRequest, got %s", entries[0].Direction)
+	}
+	if entries[1].Direction != ServerResponse {
+		t.Fatalf("expected second entry to be ServerResponse, got %s", entries[1].Direction)
+	}
+}
+
+func TestRecordingTransport_LogsErrors(t *testing.T) {
+	mock := transport.NewMock()
+	var buf bytes.Buffer
+	rt := NewRecordingTransport(mock, &buf)
+
+	var gotErr error
+	rt.SetErrorHandler(func(err error) { gotErr = err })
+
+	// handleError is what NewRecordingTransport installed as the underlying
+	// transport's error handler; drive it directly since MockTransport has
+	// no way to simulate a transport-level error itself.
+	wantErr := errors.New("boom")
+	rt.handleError(wantErr)
+	if gotErr != wantErr {
+		t.Fatalf("expected the wrapped error handler to be called")
+	}
+
+	entries, err := ReadLog(&buf)
+	if err != nil {
+		t.Fatalf("ReadLog failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Direction != Error || entries[0].Err != wantErr.Error() {
+		t.Fatalf("expected a single Error entry, got %+v", entries)
+	}
+}
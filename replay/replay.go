@@ -0,0 +1,224 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/DR1N0/mcp-go/types"
+)
+
+// Mode controls the pacing ReplayClient.Run uses between recorded requests.
+type Mode int
+
+const (
+	// AsFastAsPossible replays every recorded request back to back.
+	AsFastAsPossible Mode = iota
+	// PreserveTiming sleeps between requests to reproduce the delay
+	// observed between their recorded timestamps.
+	PreserveTiming
+)
+
+// Strictness controls how ReplayClient.Run judges a replayed response
+// against the one recorded for the same request.
+type Strictness int
+
+const (
+	// Loose only checks that a response arrived and carries the same id as
+	// the recorded one.
+	Loose Strictness = iota
+	// Strict additionally requires the replayed Result/Error to match the
+	// recorded response byte-for-byte.
+	Strict
+)
+
+// MethodFilter reports whether a recorded request for method should be
+// replayed. Returning false skips it.
+type MethodFilter func(method string) bool
+
+// ReplayOption configures a ReplayClient.
+type ReplayOption func(*ReplayClient)
+
+// WithMode sets the replay pacing. The default is AsFastAsPossible.
+func WithMode(mode Mode) ReplayOption {
+	return func(r *ReplayClient) {
+		r.mode = mode
+	}
+}
+
+// WithStrictness sets how divergences are judged. The default is Loose.
+func WithStrictness(strictness Strictness) ReplayOption {
+	return func(r *ReplayClient) {
+		r.strictness = strictness
+	}
+}
+
+// WithMethodFilter restricts replay to requests for which allowed returns
+// true.
+func WithMethodFilter(allowed MethodFilter) ReplayOption {
+	return func(r *ReplayClient) {
+		r.methodFilter = allowed
+	}
+}
+
+// Divergence describes one replayed response that didn't match what was
+// recorded for the same request.
+type Divergence struct {
+	Method   string
+	Recorded *types.BaseJSONRPCMessage
+	Replayed *types.BaseJSONRPCMessage
+	Reason   string
+}
+
+// ReplayClient drives a live MCP server transport with the client→server
+// requests and notifications from a recorded session log, in order,
+// matching the server's responses back by id and diffing them against the
+// recorded server→client frames.
+type ReplayClient struct {
+	entries      []Entry
+	transport    types.Transport
+	mode         Mode
+	strictness   Strictness
+	methodFilter MethodFilter
+
+	mu      sync.Mutex
+	pending map[interface{}]chan *types.BaseJSONRPCMessage
+}
+
+// NewReplayClient parses the session log read from r and returns a
+// ReplayClient that will drive transport - typically a client-side
+// transport (grpc, sse, stdio, streamable, or transport.NewMock) connected
+// to the server under test.
+func NewReplayClient(r io.Reader, transport types.Transport, opts ...ReplayOption) (*ReplayClient, error) {
+	entries, err := ReadLog(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := &ReplayClient{
+		entries:   entries,
+		transport: transport,
+		pending:   make(map[interface{}]chan *types.BaseJSONRPCMessage),
+	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc, nil
+}
+
+// onMessage is installed as the transport's message handler while Run is in
+// progress, routing each server→client response to the goroutine awaiting
+// it by id.
+func (r *ReplayClient) onMessage(ctx context.Context, msg *types.BaseJSONRPCMessage) {
+	if msg.ID == nil || (msg.Result == nil && msg.Error == nil) {
+		// A server-initiated request or notification - nothing recorded to
+		// correlate it against synchronously, so it's not replayed further.
+		return
+	}
+	r.mu.Lock()
+	ch, ok := r.pending[idKey(msg.ID)]
+	r.mu.Unlock()
+	if ok {
+		ch <- msg
+	}
+}
+
+// Run starts transport, replays every recorded ClientRequest and
+// ClientNotification in order, and returns the divergences found between
+// each recorded ServerResponse and what the live server actually returned.
+func (r *ReplayClient) Run(ctx context.Context) ([]Divergence, error) {
+	r.transport.SetMessageHandler(r.onMessage)
+	if err := r.transport.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start replay transport: %w", err)
+	}
+
+	var divergences []Divergence
+	var prevTimestamp time.Time
+	for i, entry := range r.entries {
+		if entry.Message == nil {
+			continue
+		}
+		if entry.Direction != ClientRequest && entry.Direction != ClientNotification {
+			continue
+		}
+		if r.methodFilter != nil && !r.methodFilter(entry.Message.Method) {
+			continue
+		}
+
+		if r.mode == PreserveTiming && !prevTimestamp.IsZero() {
+			if d := entry.Timestamp.Sub(prevTimestamp); d > 0 {
+				time.Sleep(d)
+			}
+		}
+		prevTimestamp = entry.Timestamp
+
+		var respCh chan *types.BaseJSONRPCMessage
+		if entry.Direction == ClientRequest {
+			respCh = make(chan *types.BaseJSONRPCMessage, 1)
+			r.mu.Lock()
+			r.pending[idKey(entry.Message.ID)] = respCh
+			r.mu.Unlock()
+		}
+
+		if err := r.transport.Send(ctx, entry.Message); err != nil {
+			return divergences, fmt.Errorf("failed to replay %s %q: %w", entry.Direction, entry.Message.Method, err)
+		}
+		if entry.Direction != ClientRequest {
+			continue
+		}
+
+		recorded := findRecordedResponse(r.entries, i, entry.Message.ID)
+
+		select {
+		case replayed := <-respCh:
+			r.mu.Lock()
+			delete(r.pending, idKey(entry.Message.ID))
+			r.mu.Unlock()
+			if d, diverged := r.diff(entry.Message.Method, recorded, replayed); diverged {
+				divergences = append(divergences, d)
+			}
+		case <-ctx.Done():
+			return divergences, ctx.Err()
+		}
+	}
+	return divergences, nil
+}
+
+// diff judges replayed against recorded according to r.strictness.
+func (r *ReplayClient) diff(method string, recorded, replayed *types.BaseJSONRPCMessage) (Divergence, bool) {
+	if recorded == nil {
+		return Divergence{Method: method, Replayed: replayed, Reason: "no recorded response for this request"}, true
+	}
+	if idKey(recorded.ID) != idKey(replayed.ID) {
+		return Divergence{Method: method, Recorded: recorded, Replayed: replayed, Reason: "replayed response id does not match the recorded id"}, true
+	}
+	if r.strictness == Loose {
+		return Divergence{}, false
+	}
+	if !bytes.Equal(recorded.Result, replayed.Result) || !reflect.DeepEqual(recorded.Error, replayed.Error) {
+		return Divergence{Method: method, Recorded: recorded, Replayed: replayed, Reason: "result/error diverged from the recording"}, true
+	}
+	return Divergence{}, false
+}
+
+// findRecordedResponse returns the recorded ServerResponse for id, searching
+// forward from after index i, the entry that sent the matching request.
+func findRecordedResponse(entries []Entry, i int, id interface{}) *types.BaseJSONRPCMessage {
+	for _, entry := range entries[i+1:] {
+		if entry.Direction == ServerResponse && entry.Message != nil && idKey(entry.Message.ID) == idKey(id) {
+			return entry.Message
+		}
+	}
+	return nil
+}
+
+// idKey normalizes a JSON-RPC id to a comparable string, since the same id
+// can decode as different Go types (e.g. float64 vs int64) depending on
+// whether it came off the wire or was constructed in code.
+func idKey(id interface{}) string {
+	return fmt.Sprintf("%v", id)
+}
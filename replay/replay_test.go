@@ -0,0 +1,134 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DR1N0/mcp-go/transport"
+	"github.com/DR1N0/mcp-go/types"
+)
+
+// logLine builds one newline-delimited session log entry.
+func logLine(t *testing.T, e Entry) string {
+	t.Helper()
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("failed to marshal entry: %v", err)
+	}
+	return string(data) + "\n"
+}
+
+func TestReplayClient_MatchesResponsesById(t *testing.T) {
+	log := logLine(t, Entry{Direction: ClientRequest, Timestamp: time.Unix(0, 0),
+		Message: &types.BaseJSONRPCMessage{JSONRPC: "2.0", ID: float64(1), Method: "ping"}}) +
+		logLine(t, Entry{Direction: ServerResponse, Timestamp: time.Unix(0, 1),
+			Message: &types.BaseJSONRPCMessage{JSONRPC: "2.0", ID: float64(1), Result: json.RawMessage(`{}`)}})
+
+	mock := transport.NewMock()
+	rc, err := NewReplayClient(strings.NewReader(log), mock)
+	if err != nil {
+		t.Fatalf("NewReplayClient failed: %v", err)
+	}
+
+	// Act as the live server: reply to whatever request comes in on a
+	// separate goroutine, since Run blocks waiting for the response.
+	go func() {
+		for {
+			sent := mock.GetSentMessages()
+			if len(sent) == 0 {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			req := sent[len(sent)-1]
+			mock.SimulateReceive(context.Background(), &types.BaseJSONRPCMessage{
+				JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(`{}`),
+			})
+			return
+		}
+	}()
+
+	divergences, err := rc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(divergences) != 0 {
+		t.Fatalf("expected no divergences, got %+v", divergences)
+	}
+}
+
+func TestReplayClient_StrictModeCatchesResultDivergence(t *testing.T) {
+	log := logLine(t, Entry{Direction: ClientRequest, Timestamp: time.Unix(0, 0),
+		Message: &types.BaseJSONRPCMessage{JSONRPC: "2.0", ID: float64(1), Method: "ping"}}) +
+		logLine(t, Entry{Direction: ServerResponse, Timestamp: time.Unix(0, 1),
+			Message: &types.BaseJSONRPCMessage{JSONRPC: "2.0", ID: float64(1), Result: json.RawMessage(`{"status":"ok"}`)}})
+
+	mock := transport.NewMock()
+	rc, err := NewReplayClient(strings.NewReader(log), mock, WithStrictness(Strict))
+	if err != nil {
+		t.Fatalf("NewReplayClient failed: %v", err)
+	}
+
+	go func() {
+		for {
+			sent := mock.GetSentMessages()
+			if len(sent) == 0 {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			req := sent[len(sent)-1]
+			mock.SimulateReceive(context.Background(), &types.BaseJSONRPCMessage{
+				JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(`{"status":"degraded"}`),
+			})
+			return
+		}
+	}()
+
+	divergences, err := rc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(divergences) != 1 {
+		t.Fatalf("expected one divergence, got %+v", divergences)
+	}
+}
+
+func TestReplayClient_MethodFilterSkipsRequests(t *testing.T) {
+	log := logLine(t, Entry{Direction: ClientNotification, Timestamp: time.Unix(0, 0),
+		Message: &types.BaseJSONRPCMessage{JSONRPC: "2.0", Method: "notifications/initialized"}})
+
+	mock := transport.NewMock()
+	rc, err := NewReplayClient(strings.NewReader(log), mock, WithMethodFilter(func(method string) bool {
+		return method != "notifications/initialized"
+	}))
+	if err != nil {
+		t.Fatalf("NewReplayClient failed: %v", err)
+	}
+
+	if _, err := rc.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := mock.GetSentMessages(); len(got) != 0 {
+		t.Fatalf("expected the filtered notification not to be replayed, got %v", got)
+	}
+}
+
+func TestReadLog_RoundTrips(t *testing.T) {
+	e := Entry{Direction: ClientRequest, Timestamp: time.Unix(0, 0).UTC(),
+		Message: &types.BaseJSONRPCMessage{JSONRPC: "2.0", ID: float64(1), Method: "ping"}}
+	var buf bytes.Buffer
+	data, _ := json.Marshal(e)
+	buf.Write(data)
+	buf.WriteByte('\n')
+
+	entries, err := ReadLog(&buf)
+	if err != nil {
+		t.Fatalf("ReadLog failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Message.Method != "ping" {
+		t.Fatalf("expected to round-trip the entry, got %+v", entries)
+	}
+}
@@ -0,0 +1,239 @@
+// Package schema generates JSON Schema documents from Go types for use as
+// MCP tool input schemas.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// deadliner detects the context.Context parameter of a tool handler without
+// importing the context package directly: any type with context.Context's
+// Deadline method is taken to be the context parameter.
+type deadliner interface {
+	Deadline() (deadline time.Time, ok bool)
+}
+
+// Generator produces a JSON Schema for a Go type. Implement it to plug in a
+// third-party reflector, such as github.com/invopop/jsonschema, in place of
+// the built-in Reflector.
+type Generator interface {
+	Generate(t reflect.Type) (map[string]interface{}, error)
+}
+
+// Option configures a Reflector.
+type Option func(*Reflector)
+
+// WithGenerator swaps the schema generator used for the handler's argument
+// type, letting a tool author use a third-party generator instead of the
+// built-in recursive walker.
+func WithGenerator(gen Generator) Option {
+	return func(r *Reflector) {
+		r.generator = gen
+	}
+}
+
+// Reflector walks a Go type and produces a JSON Schema document. Nested
+// structs are recursed into and collected under "$defs", with repeated or
+// cyclic references to the same struct type emitted as "$ref" rather than
+// being inlined again.
+type Reflector struct {
+	generator Generator
+}
+
+// NewReflector creates a Reflector, applying any Options in order.
+func NewReflector(opts ...Option) *Reflector {
+	r := &Reflector{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Reflect produces the JSON Schema for t. If a Generator was installed via
+// WithGenerator, it is used instead of the built-in walker.
+func (r *Reflector) Reflect(t reflect.Type) (map[string]interface{}, error) {
+	if r.generator != nil {
+		return r.generator.Generate(t)
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("argument must be a struct")
+	}
+
+	defs := map[string]interface{}{}
+	names := map[reflect.Type]string{}
+
+	root, err := r.structSchema(t, defs, names)
+	if err != nil {
+		return nil, err
+	}
+	if len(defs) > 0 {
+		root["$defs"] = defs
+	}
+	return root, nil
+}
+
+// ReflectHandler produces the JSON Schema for handler's argument struct,
+// skipping a leading context.Context parameter and treating a handler that
+// takes no arguments as an empty object schema.
+func (r *Reflector) ReflectHandler(handler interface{}) (map[string]interface{}, error) {
+	handlerType := reflect.TypeOf(handler)
+	if handlerType.Kind() != reflect.Func {
+		return nil, fmt.Errorf("handler must be a function")
+	}
+
+	numIn := handlerType.NumIn()
+	var argType reflect.Type
+
+	if numIn > 0 && handlerType.In(0).Implements(reflect.TypeOf((*deadliner)(nil)).Elem()) {
+		if numIn > 1 {
+			argType = handlerType.In(1)
+		}
+	} else if numIn > 0 {
+		argType = handlerType.In(0)
+	}
+
+	if argType == nil {
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		}, nil
+	}
+
+	return r.Reflect(argType)
+}
+
+// structSchema builds the "object" schema for a struct type, recursing into
+// its fields via fieldSchema.
+func (r *Reflector) structSchema(t reflect.Type, defs map[string]interface{}, names map[reflect.Type]string) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldName, omit := jsonFieldName(field)
+		if omit {
+			continue
+		}
+
+		fieldSchema, err := r.fieldSchema(field, defs, names)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		constraints := parseTag(field.Tag.Get("jsonschema"))
+		constraints.apply(fieldSchema)
+
+		properties[fieldName] = fieldSchema
+
+		if constraints.required || field.Type.Kind() != reflect.Ptr {
+			required = append(required, fieldName)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// fieldSchema produces the schema for a single struct field's type,
+// recursing into slices, maps, and nested structs.
+func (r *Reflector) fieldSchema(field reflect.StructField, defs map[string]interface{}, names map[reflect.Type]string) (map[string]interface{}, error) {
+	return r.typeSchema(field.Type, defs, names)
+}
+
+// typeSchema produces the schema for t, recursing into element/value types
+// for slices and maps, and $ref-ing repeated struct types via $defs.
+func (r *Reflector) typeSchema(t reflect.Type, defs map[string]interface{}, names map[reflect.Type]string) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := r.typeSchema(t.Elem(), defs, names)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		additional, err := r.typeSchema(t.Elem(), defs, names)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": additional}, nil
+	case reflect.Struct:
+		return r.refSchema(t, defs, names)
+	default:
+		return map[string]interface{}{"type": "string"}, nil
+	}
+}
+
+// refSchema returns a "$ref" into "$defs" for a named struct type,
+// generating its definition on first sight. Registering the (possibly
+// empty) def name before recursing into the struct's fields is what makes
+// self-referential and mutually-referential structs terminate instead of
+// recursing forever.
+func (r *Reflector) refSchema(t reflect.Type, defs map[string]interface{}, names map[reflect.Type]string) (map[string]interface{}, error) {
+	name, ok := names[t]
+	if !ok {
+		name = defName(t, names)
+		names[t] = name
+		defs[name] = map[string]interface{}{} // placeholder, breaks cycles
+
+		def, err := r.structSchema(t, defs, names)
+		if err != nil {
+			return nil, err
+		}
+		defs[name] = def
+	}
+	return map[string]interface{}{"$ref": "#/$defs/" + name}, nil
+}
+
+// defName picks a unique $defs key for t, falling back to a positional
+// name for anonymous struct types.
+func defName(t reflect.Type, names map[reflect.Type]string) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return fmt.Sprintf("anon%d", len(names))
+}
+
+// jsonFieldName resolves a struct field's schema property name from its
+// json tag, and reports whether the field should be omitted entirely.
+func jsonFieldName(field reflect.StructField) (name string, omit bool) {
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "-" {
+		return "", true
+	}
+
+	name = field.Name
+	if parts := strings.Split(jsonTag, ","); parts[0] != "" {
+		name = parts[0]
+	}
+	return name, false
+}
@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+type address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip" jsonschema:"pattern=^[0-9]{5}$"`
+}
+
+type person struct {
+	Name    string    `json:"name" jsonschema:"required,description=Full name"`
+	Age     int       `json:"age" jsonschema:"minimum=0,maximum=150"`
+	Role    string    `json:"role" jsonschema:"enum=admin|member|guest,default=member"`
+	Tags    []string  `json:"tags"`
+	Home    address   `json:"home"`
+	Manager *person   `json:"manager"`
+	Friends []*person `json:"friends"`
+}
+
+func TestReflect_NestedStructUsesRef(t *testing.T) {
+	schema, err := NewReflector().Reflect(reflect.TypeOf(person{}))
+	if err != nil {
+		t.Fatalf("Reflect failed: %v", err)
+	}
+
+	props := schema["properties"].(map[string]interface{})
+	home := props["home"].(map[string]interface{})
+	if _, ok := home["$ref"]; !ok {
+		t.Errorf("expected nested struct field to be a $ref, got %v", home)
+	}
+
+	defs, ok := schema["$defs"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected $defs to be populated")
+	}
+	if _, ok := defs["address"]; !ok {
+		t.Errorf("expected $defs to contain address, got %v", defs)
+	}
+}
+
+func TestReflect_SelfReferentialStructTerminates(t *testing.T) {
+	// person.Manager and person.Friends both reference person itself; this
+	// must terminate via $ref rather than recursing forever.
+	if _, err := NewReflector().Reflect(reflect.TypeOf(person{})); err != nil {
+		t.Fatalf("Reflect failed: %v", err)
+	}
+}
+
+func TestReflect_ArrayHasItems(t *testing.T) {
+	schema, err := NewReflector().Reflect(reflect.TypeOf(person{}))
+	if err != nil {
+		t.Fatalf("Reflect failed: %v", err)
+	}
+
+	props := schema["properties"].(map[string]interface{})
+	tags := props["tags"].(map[string]interface{})
+	if tags["type"] != "array" {
+		t.Fatalf("expected tags to be an array, got %v", tags)
+	}
+	items, ok := tags["items"].(map[string]interface{})
+	if !ok || items["type"] != "string" {
+		t.Errorf("expected tags.items to be {type: string}, got %v", tags["items"])
+	}
+}
+
+func TestReflect_TagConstraints(t *testing.T) {
+	schema, err := NewReflector().Reflect(reflect.TypeOf(person{}))
+	if err != nil {
+		t.Fatalf("Reflect failed: %v", err)
+	}
+
+	props := schema["properties"].(map[string]interface{})
+
+	age := props["age"].(map[string]interface{})
+	if age["minimum"] != 0.0 || age["maximum"] != 150.0 {
+		t.Errorf("expected age min/max constraints, got %v", age)
+	}
+
+	role := props["role"].(map[string]interface{})
+	enum, ok := role["enum"].([]interface{})
+	if !ok || len(enum) != 3 {
+		t.Fatalf("expected role enum with 3 values, got %v", role["enum"])
+	}
+	if role["default"] != "member" {
+		t.Errorf("expected role default 'member', got %v", role["default"])
+	}
+}
+
+func TestWithGenerator_Overrides(t *testing.T) {
+	stub := stubGenerator{result: map[string]interface{}{"type": "object"}}
+	r := NewReflector(WithGenerator(stub))
+
+	got, err := r.Reflect(reflect.TypeOf(person{}))
+	if err != nil {
+		t.Fatalf("Reflect failed: %v", err)
+	}
+	if got["type"] != "object" || len(got) != 1 {
+		t.Errorf("expected the stub generator's result to be used verbatim, got %v", got)
+	}
+}
+
+type stubGenerator struct {
+	result map[string]interface{}
+}
+
+func (s stubGenerator) Generate(reflect.Type) (map[string]interface{}, error) {
+	return s.result, nil
+}
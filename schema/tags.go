@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"strconv"
+	"strings"
+)
+
+// constraints holds the parsed `jsonschema:"..."` tag vocabulary for a
+// struct field. Recognized keys: required, description=..., enum=a|b|c,
+// minimum=N, maximum=N, minLength=N, maxLength=N, pattern=..., format=...,
+// default=..., example=....
+type constraints struct {
+	required    bool
+	description string
+	enum        []string
+	minimum     *float64
+	maximum     *float64
+	minLength   *int
+	maxLength   *int
+	pattern     string
+	format      string
+	def         string
+	hasDefault  bool
+	example     string
+	hasExample  bool
+}
+
+// parseTag parses a struct field's jsonschema tag into constraints. Unknown
+// or malformed entries are ignored rather than treated as errors, since a
+// bad tag shouldn't stop a tool from registering.
+func parseTag(tag string) constraints {
+	var c constraints
+	if tag == "" {
+		return c
+	}
+
+	for _, part := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "required":
+			c.required = true
+		case "description":
+			c.description = value
+		case "enum":
+			if hasValue && value != "" {
+				c.enum = strings.Split(value, "|")
+			}
+		case "minimum":
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				c.minimum = &v
+			}
+		case "maximum":
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				c.maximum = &v
+			}
+		case "minLength":
+			if v, err := strconv.Atoi(value); err == nil {
+				c.minLength = &v
+			}
+		case "maxLength":
+			if v, err := strconv.Atoi(value); err == nil {
+				c.maxLength = &v
+			}
+		case "pattern":
+			c.pattern = value
+		case "format":
+			c.format = value
+		case "default":
+			c.def = value
+			c.hasDefault = hasValue
+		case "example":
+			c.example = value
+			c.hasExample = hasValue
+		}
+	}
+	return c
+}
+
+// apply folds the parsed constraints into a field's JSON Schema, coercing
+// enum/default/example values to match the schema's declared "type" where
+// that type is a simple scalar.
+func (c constraints) apply(fieldSchema map[string]interface{}) {
+	if c.description != "" {
+		fieldSchema["description"] = c.description
+	}
+	if len(c.enum) > 0 {
+		values := make([]interface{}, len(c.enum))
+		for i, v := range c.enum {
+			values[i] = coerce(fieldSchema, v)
+		}
+		fieldSchema["enum"] = values
+	}
+	if c.minimum != nil {
+		fieldSchema["minimum"] = *c.minimum
+	}
+	if c.maximum != nil {
+		fieldSchema["maximum"] = *c.maximum
+	}
+	if c.minLength != nil {
+		fieldSchema["minLength"] = *c.minLength
+	}
+	if c.maxLength != nil {
+		fieldSchema["maxLength"] = *c.maxLength
+	}
+	if c.pattern != "" {
+		fieldSchema["pattern"] = c.pattern
+	}
+	if c.format != "" {
+		fieldSchema["format"] = c.format
+	}
+	if c.hasDefault {
+		fieldSchema["default"] = coerce(fieldSchema, c.def)
+	}
+	if c.hasExample {
+		fieldSchema["example"] = coerce(fieldSchema, c.example)
+	}
+}
+
+// coerce converts a raw tag string to the Go value matching fieldSchema's
+// "type", falling back to the raw string when the type isn't a simple
+// scalar or the conversion fails.
+func coerce(fieldSchema map[string]interface{}, raw string) interface{} {
+	switch fieldSchema["type"] {
+	case "integer":
+		if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(raw, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(raw); err == nil {
+			return v
+		}
+	}
+	return raw
+}
@@ -10,15 +10,92 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/DR1N0/mcp-go/observability"
 	"github.com/DR1N0/mcp-go/protocol"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ProgressReporter reports progress on a long-running tool call back to the
+// caller. Tool handlers opt in by taking a ProgressReporter as their last
+// argument; it is a no-op when the caller didn't request progress updates.
+type ProgressReporter func(progress float64, total *float64) error
+
+// ToolHandler invokes the next stage of a UnaryToolInterceptor chain, or the
+// registered tool handler itself if it's the innermost stage.
+type ToolHandler func(ctx context.Context, name string, args interface{}) (*ToolResponse, error)
+
+// UnaryToolInterceptor wraps a tools/call dispatch, seeing the decoded tool
+// name and typed arguments before the registered handler runs - useful for
+// per-tool auth checks, structured logging, metrics, or rate limiting. It
+// can short-circuit the call by returning its own response or an RPCError,
+// or mutate the response coming back from next.
+type UnaryToolInterceptor func(ctx context.Context, name string, args interface{}, next ToolHandler) (*ToolResponse, error)
+
+// PromptHandler invokes the next stage of a PromptInterceptor chain, or the
+// registered prompt handler itself if it's the innermost stage.
+type PromptHandler func(ctx context.Context, name string, args interface{}) (*PromptResponse, error)
+
+// PromptInterceptor wraps a prompts/get dispatch, seeing the decoded prompt
+// name and arguments before the registered handler runs.
+type PromptInterceptor func(ctx context.Context, name string, args interface{}, next PromptHandler) (*PromptResponse, error)
+
+// ResourceHandler invokes the next stage of a ResourceInterceptor chain, or
+// the registered resource handler itself if it's the innermost stage.
+type ResourceHandler func(ctx context.Context, uri string) (*ResourceResponse, error)
+
+// ResourceInterceptor wraps a resources/read dispatch, seeing the decoded
+// resource URI before the registered handler runs.
+type ResourceInterceptor func(ctx context.Context, uri string, next ResourceHandler) (*ResourceResponse, error)
+
+// chainToolCall composes interceptors, in order, around final so that
+// interceptors[0] runs outermost
+func chainToolCall(interceptors []UnaryToolInterceptor, final ToolHandler) ToolHandler {
+	chained := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := chained
+		chained = func(ctx context.Context, name string, args interface{}) (*ToolResponse, error) {
+			return interceptor(ctx, name, args, next)
+		}
+	}
+	return chained
+}
+
+// chainPromptCall is chainToolCall's counterpart for PromptInterceptor
+func chainPromptCall(interceptors []PromptInterceptor, final PromptHandler) PromptHandler {
+	chained := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := chained
+		chained = func(ctx context.Context, name string, args interface{}) (*PromptResponse, error) {
+			return interceptor(ctx, name, args, next)
+		}
+	}
+	return chained
+}
+
+// chainResourceCall is chainToolCall's counterpart for ResourceInterceptor
+func chainResourceCall(interceptors []ResourceInterceptor, final ResourceHandler) ResourceHandler {
+	chained := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := chained
+		chained = func(ctx context.Context, uri string) (*ResourceResponse, error) {
+			return interceptor(ctx, uri, next)
+		}
+	}
+	return chained
+}
+
 // registeredTool holds a tool's metadata and handler
 type registeredTool struct {
-	name        string
-	description *string
-	handler     interface{}
-	inputSchema map[string]interface{}
+	name         string
+	description  *string
+	handler      interface{}
+	inputSchema  map[string]interface{}
+	outputSchema map[string]interface{} // Only set via WithToolOutputSchema; advertised to newer clients only
+	annotations  map[string]interface{} // Only set via WithToolAnnotations; advertised to newer clients only
 }
 
 // registeredPrompt holds a prompt's metadata and handler
@@ -36,19 +113,38 @@ type registeredResource struct {
 	description *string
 	mimeType    *string
 	handler     interface{}
+	watcher     ResourceWatcher
+	watchCancel context.CancelFunc // non-nil while watcher's Watch goroutine is running
 }
 
 // MCPServer implements the Server interface with automatic tool management
 type MCPServer struct {
-	transport       Transport
-	protocol        protocol.Protocol
-	info            ServerInfo
-	paginationLimit int
-	started         bool         // Tracks if Serve() has been called
-	mu              sync.RWMutex // Protects tools, prompts, resources, started
-	tools           map[string]*registeredTool
-	prompts         map[string]*registeredPrompt
-	resources       map[string]*registeredResource
+	transport            Transport
+	protocol             protocol.Protocol
+	protocolOpts         []protocol.Option // Collected from options, consumed when the protocol is constructed
+	info                 ServerInfo
+	paginationLimit      int
+	started              bool         // Tracks if Serve() has been called
+	mu                   sync.RWMutex // Protects tools, prompts, resources, started
+	tools                map[string]*registeredTool
+	prompts              map[string]*registeredPrompt
+	resources            map[string]*registeredResource
+	resourceWatcherCount int // number of resources registered with a ResourceWatcher
+
+	toolInterceptors     []UnaryToolInterceptor
+	promptInterceptors   []PromptInterceptor
+	resourceInterceptors []ResourceInterceptor
+
+	resourceUpdatePolicy ResourceUpdatePolicy
+	resourceHub          *resourceHub
+
+	protocolVersion          string            // Preferred version to negotiate; "" means defaultProtocolVersion
+	capabilityOverrides      map[string]ServerCapabilities
+	sessionVersions          map[string]string // session_id -> negotiated protocol version
+	defaultNegotiatedVersion string            // negotiated version for transports with no session_id
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
 }
 
 // ServerOption configures the server
@@ -75,11 +171,85 @@ func WithPaginationLimit(limit int) ServerOption {
 	}
 }
 
+// WithServerInterceptors chains interceptors, in order, around the dispatch
+// of every incoming request (tools/call, prompts/get, etc.) to its handler -
+// useful for cross-cutting concerns like auth, logging, or metrics
+func WithServerInterceptors(interceptors ...protocol.ServerInterceptor) ServerOption {
+	return func(s *MCPServer) {
+		s.protocolOpts = append(s.protocolOpts, protocol.WithServerInterceptors(interceptors...))
+	}
+}
+
+// WithServerNotificationInterceptors chains interceptors, in order, around
+// the dispatch of every incoming notification to its handler
+func WithServerNotificationInterceptors(interceptors ...protocol.ServerNotificationInterceptor) ServerOption {
+	return func(s *MCPServer) {
+		s.protocolOpts = append(s.protocolOpts, protocol.WithServerNotificationInterceptors(interceptors...))
+	}
+}
+
+// WithToolInterceptors chains interceptors, in order, around the dispatch
+// of every tools/call to its handler, seeing the decoded tool name and
+// typed arguments rather than the raw JSON-RPC params WithServerInterceptors
+// sees
+func WithToolInterceptors(interceptors ...UnaryToolInterceptor) ServerOption {
+	return func(s *MCPServer) {
+		s.toolInterceptors = append(s.toolInterceptors, interceptors...)
+	}
+}
+
+// WithPromptInterceptors chains interceptors, in order, around the dispatch
+// of every prompts/get to its handler
+func WithPromptInterceptors(interceptors ...PromptInterceptor) ServerOption {
+	return func(s *MCPServer) {
+		s.promptInterceptors = append(s.promptInterceptors, interceptors...)
+	}
+}
+
+// WithResourceInterceptors chains interceptors, in order, around the
+// dispatch of every resources/read to its handler
+func WithResourceInterceptors(interceptors ...ResourceInterceptor) ServerOption {
+	return func(s *MCPServer) {
+		s.resourceInterceptors = append(s.resourceInterceptors, interceptors...)
+	}
+}
+
+// WithResourceUpdateBackpressure controls what NotifyResourceUpdated does
+// when a subscribed session's update queue is already full: DropOldestOnFull
+// (the default) discards the oldest undelivered update, while BlockOnFull
+// waits for the session to catch up.
+func WithResourceUpdateBackpressure(policy ResourceUpdatePolicy) ServerOption {
+	return func(s *MCPServer) {
+		s.resourceUpdatePolicy = policy
+	}
+}
+
+// WithTracerProvider installs OpenTelemetry tracing around the dispatch of
+// every incoming request, via observability.NewServerInterceptor - one span
+// per call, named "mcp.tool/<name>" for tools/call and "mcp.<method>"
+// otherwise, continuing the caller's trace when the request carries a
+// "_meta.traceparent". Defaults to the globally registered TracerProvider
+// (a no-op tracer if none was set).
+func WithTracerProvider(tp trace.TracerProvider) ServerOption {
+	return func(s *MCPServer) {
+		s.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider installs request count, duration, in-flight, and
+// per-error-code metrics around the dispatch of every incoming request, via
+// observability.NewServerInterceptor. Defaults to the globally registered
+// MeterProvider (a no-op meter if none was set).
+func WithMeterProvider(mp metric.MeterProvider) ServerOption {
+	return func(s *MCPServer) {
+		s.meterProvider = mp
+	}
+}
+
 // NewServer creates a new MCP server
 func NewServer(transport Transport, opts ...ServerOption) Server {
 	server := &MCPServer{
 		transport:       transport,
-		protocol:        protocol.NewProtocol(),
 		paginationLimit: 10, // Default pagination limit
 		info: ServerInfo{
 			Name:    "mcp-server",
@@ -90,11 +260,25 @@ func NewServer(transport Transport, opts ...ServerOption) Server {
 		resources: make(map[string]*registeredResource),
 	}
 
-	// Apply options
+	// Apply options before constructing the protocol, so options like
+	// WithServerInterceptors can configure it
 	for _, opt := range opts {
 		opt(server)
 	}
 
+	if server.tracerProvider != nil || server.meterProvider != nil {
+		server.protocolOpts = append(server.protocolOpts, protocol.WithServerInterceptors(
+			observability.NewServerInterceptor(
+				observability.WithTracerProvider(server.tracerProvider),
+				observability.WithMeterProvider(server.meterProvider),
+				observability.WithTransportKind(fmt.Sprintf("%T", transport)),
+			),
+		))
+	}
+
+	server.protocol = protocol.NewProtocol(server.protocolOpts...)
+	server.resourceHub = newResourceHub(server.resourceUpdatePolicy, server.notifySession)
+
 	// Register MCP protocol handlers
 	server.protocol.SetRequestHandler("initialize", server.handleInitialize)
 	server.protocol.SetRequestHandler("tools/list", server.handleToolsList)
@@ -103,13 +287,15 @@ func NewServer(transport Transport, opts ...ServerOption) Server {
 	server.protocol.SetRequestHandler("prompts/get", server.handlePromptsGet)
 	server.protocol.SetRequestHandler("resources/list", server.handleResourcesList)
 	server.protocol.SetRequestHandler("resources/read", server.handleResourceRead)
+	server.protocol.SetRequestHandler("resources/subscribe", server.handleResourcesSubscribe)
+	server.protocol.SetRequestHandler("resources/unsubscribe", server.handleResourcesUnsubscribe)
 	server.protocol.SetRequestHandler("ping", server.handlePing)
 
 	return server
 }
 
 // RegisterTool registers a tool with automatic schema generation
-func (s *MCPServer) RegisterTool(name, description string, handler interface{}) error {
+func (s *MCPServer) RegisterTool(name, description string, handler interface{}, opts ...ToolOption) error {
 	// Validate handler is a function
 	handlerType := reflect.TypeOf(handler)
 	if handlerType.Kind() != reflect.Func {
@@ -122,14 +308,19 @@ func (s *MCPServer) RegisterTool(name, description string, handler interface{})
 		return fmt.Errorf("failed to generate schema: %w", err)
 	}
 
-	s.mu.Lock()
 	desc := &description
-	s.tools[name] = &registeredTool{
+	tool := &registeredTool{
 		name:        name,
 		description: desc,
 		handler:     handler,
 		inputSchema: schema,
 	}
+	for _, opt := range opts {
+		opt(tool)
+	}
+
+	s.mu.Lock()
+	s.tools[name] = tool
 	s.mu.Unlock()
 
 	log.Printf("Registered tool: %s", name)
@@ -170,24 +361,41 @@ func (s *MCPServer) RegisterPrompt(name, description string, handler interface{}
 	return nil
 }
 
-// RegisterResource registers a resource
-func (s *MCPServer) RegisterResource(uri, name, description, mimeType string, handler interface{}) error {
+// RegisterResource registers a resource. opts may include
+// WithResourceWatcher to push update notifications into the server's
+// subscription hub without a client having to poll resources/read.
+func (s *MCPServer) RegisterResource(uri, name, description, mimeType string, handler interface{}, opts ...ResourceOption) error {
 	// Validate handler is a function
 	handlerType := reflect.TypeOf(handler)
 	if handlerType.Kind() != reflect.Func {
 		return fmt.Errorf("handler must be a function")
 	}
 
-	s.mu.Lock()
 	desc := &description
 	mime := &mimeType
-	s.resources[uri] = &registeredResource{
+	resource := &registeredResource{
 		uri:         uri,
 		name:        name,
 		description: desc,
 		mimeType:    mime,
 		handler:     handler,
 	}
+	for _, opt := range opts {
+		opt(resource)
+	}
+
+	s.mu.Lock()
+	if existing, ok := s.resources[uri]; ok && existing.watchCancel != nil {
+		existing.watchCancel()
+		s.resourceWatcherCount--
+	}
+	if resource.watcher != nil {
+		watchCtx, cancel := context.WithCancel(context.Background())
+		resource.watchCancel = cancel
+		s.resourceWatcherCount++
+		go s.runResourceWatcher(watchCtx, uri, resource.watcher)
+	}
+	s.resources[uri] = resource
 	s.mu.Unlock()
 
 	log.Printf("Registered resource: %s (%s)", name, uri)
@@ -198,6 +406,15 @@ func (s *MCPServer) RegisterResource(uri, name, description, mimeType string, ha
 	return nil
 }
 
+// runResourceWatcher runs w for uri until ctx is canceled - by a later
+// RegisterResource for the same uri, DeregisterResource, or Close -
+// notifying every subscribed session of each change it reports.
+func (s *MCPServer) runResourceWatcher(ctx context.Context, uri string, w ResourceWatcher) {
+	if err := w.Watch(ctx, uri, func() { s.NotifyResourceUpdated(uri) }); err != nil && ctx.Err() == nil {
+		log.Printf("resource watcher for %s stopped: %v", uri, err)
+	}
+}
+
 // Serve starts the server
 func (s *MCPServer) Serve() error {
 	// Connect protocol to transport
@@ -216,33 +433,50 @@ func (s *MCPServer) Serve() error {
 
 // Close shuts down the server
 func (s *MCPServer) Close() error {
+	s.mu.Lock()
+	for _, resource := range s.resources {
+		if resource.watchCancel != nil {
+			resource.watchCancel()
+		}
+	}
+	s.mu.Unlock()
+
+	s.resourceHub.close()
+
 	return s.protocol.Close()
 }
 
+// hasResourceWatchers reports whether any registered resource has a
+// ResourceWatcher, i.e. whether the server can honor resources/subscribe.
+func (s *MCPServer) hasResourceWatchers() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.resourceWatcherCount > 0
+}
+
 // handleInitialize handles the initialize request
 func (s *MCPServer) handleInitialize(ctx context.Context, params interface{}) (interface{}, error) {
 	log.Println("Handling initialize request")
 
-	capabilities := ServerCapabilities{}
-
-	// Advertise tools capability (always, even if empty, to support dynamic registration)
-	capabilities.Tools = &ToolsCapability{
-		ListChanged: boolPtr(true), // Support dynamic registration
+	var clientVersion string
+	if paramsMap, ok := params.(map[string]interface{}); ok {
+		clientVersion, _ = paramsMap["protocolVersion"].(string)
 	}
 
-	// Advertise prompts capability
-	capabilities.Prompts = &PromptsCapability{
-		ListChanged: boolPtr(true), // Support dynamic registration
-	}
+	version, capabilities := s.negotiateVersion(clientVersion)
+	s.recordNegotiatedVersion(ctx, version)
 
-	// Advertise resources capability
-	capabilities.Resources = &ResourcesCapability{
-		Subscribe:   boolPtr(false),
-		ListChanged: boolPtr(true), // Support dynamic registration
+	// Resources.Subscribe depends on whether any resource has a
+	// ResourceWatcher, not on the negotiated protocol version, so it's
+	// applied on top of whatever capability set was negotiated.
+	if capabilities.Resources != nil {
+		resources := *capabilities.Resources
+		resources.Subscribe = boolPtr(s.hasResourceWatchers())
+		capabilities.Resources = &resources
 	}
 
 	return InitializeResponse{
-		ProtocolVersion: "2024-11-05",
+		ProtocolVersion: version,
 		Capabilities:    capabilities,
 		ServerInfo:      s.info,
 	}, nil
@@ -251,6 +485,7 @@ func (s *MCPServer) handleInitialize(ctx context.Context, params interface{}) (i
 // handleToolsList handles the tools/list request
 func (s *MCPServer) handleToolsList(ctx context.Context, params interface{}) (interface{}, error) {
 	log.Println("Handling tools/list request")
+	ctx = s.withNegotiatedVersion(ctx)
 
 	// Parse cursor from params
 	var cursor *string
@@ -262,17 +497,24 @@ func (s *MCPServer) handleToolsList(ctx context.Context, params interface{}) (in
 		}
 	}
 
+	extended := s.supportsExtendedToolFields(NegotiatedVersion(ctx))
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	// Collect all tools
 	allTools := make([]Tool, 0, len(s.tools))
 	for _, tool := range s.tools {
-		allTools = append(allTools, Tool{
+		t := Tool{
 			Name:        tool.name,
 			Description: tool.description,
 			InputSchema: tool.inputSchema,
-		})
+		}
+		if extended {
+			t.OutputSchema = tool.outputSchema
+			t.Annotations = tool.annotations
+		}
+		allTools = append(allTools, t)
 	}
 
 	// Sort by name for consistent pagination
@@ -320,6 +562,7 @@ func (s *MCPServer) handleToolsList(ctx context.Context, params interface{}) (in
 // handleToolCall handles the tools/call request
 func (s *MCPServer) handleToolCall(ctx context.Context, params interface{}) (interface{}, error) {
 	log.Println("Handling tools/call request")
+	ctx = s.withNegotiatedVersion(ctx)
 
 	// Parse params
 	paramsMap, ok := params.(map[string]interface{})
@@ -350,9 +593,20 @@ func (s *MCPServer) handleToolCall(ctx context.Context, params interface{}) (int
 
 	log.Printf("Calling tool: %s with args: %v", toolName, arguments)
 
-	// Call the handler
-	result, err := s.callToolHandler(tool.handler, arguments)
+	reporter := s.progressReporterFor(paramsMap["_meta"])
+	stream := s.toolStreamFor(paramsMap["_meta"])
+
+	// Call the handler, routed through any registered tool interceptors
+	final := func(ctx context.Context, name string, args interface{}) (*ToolResponse, error) {
+		return s.callToolHandler(ctx, tool.handler, args, reporter, stream)
+	}
+	result, err := chainToolCall(s.toolInterceptors, final)(ctx, toolName, arguments)
 	if err != nil {
+		// An interceptor that short-circuits with an RPCError wants a real
+		// JSON-RPC error, not a tool result with isError:true
+		if rpcErr, ok := err.(*RPCError); ok {
+			return nil, rpcErr
+		}
 		return ToolResponse{
 			Content: []Content{
 				{Type: "text", Text: strPtr(fmt.Sprintf("Error: %v", err))},
@@ -364,8 +618,55 @@ func (s *MCPServer) handleToolCall(ctx context.Context, params interface{}) (int
 	return result, nil
 }
 
+// progressReporterType is the reflect.Type of ProgressReporter, used to
+// detect handlers that accept one as their trailing argument
+var progressReporterType = reflect.TypeOf(ProgressReporter(nil))
+
+// progressReporterFor builds a ProgressReporter that sends a
+// notifications/progress message for the progress token carried in a
+// request's _meta field, or nil if the caller didn't request progress
+func (s *MCPServer) progressReporterFor(meta interface{}) ProgressReporter {
+	metaMap, ok := meta.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	token, ok := metaMap["progressToken"]
+	if !ok {
+		return nil
+	}
+
+	return func(progress float64, total *float64) error {
+		return s.protocol.Notification("notifications/progress", ProgressParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+		})
+	}
+}
+
+// toolStreamType is the reflect.Type of ToolStream, used to detect handlers
+// that accept one as their trailing argument
+var toolStreamType = reflect.TypeOf((*ToolStream)(nil)).Elem()
+
+// toolStreamFor builds a ToolStream that tags notifications/tools/partial
+// and notifications/progress messages with the progress token carried in a
+// request's _meta field, or nil if the caller didn't request progress
+// tracking (and so has no token to correlate messages back to this call)
+func (s *MCPServer) toolStreamFor(meta interface{}) ToolStream {
+	metaMap, ok := meta.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	token, ok := metaMap["progressToken"]
+	if !ok {
+		return nil
+	}
+
+	return &toolStream{protocol: s.protocol, token: token}
+}
+
 // callToolHandler calls the tool handler with proper argument unmarshaling
-func (s *MCPServer) callToolHandler(handler interface{}, arguments interface{}) (*ToolResponse, error) {
+func (s *MCPServer) callToolHandler(ctx context.Context, handler interface{}, arguments interface{}, reporter ProgressReporter, stream ToolStream) (*ToolResponse, error) {
 	handlerValue := reflect.ValueOf(handler)
 	handlerType := handlerValue.Type()
 
@@ -373,6 +674,15 @@ func (s *MCPServer) callToolHandler(handler interface{}, arguments interface{})
 	numIn := handlerType.NumIn()
 	hasContext := numIn > 0 && handlerType.In(0).Implements(reflect.TypeOf((*context.Context)(nil)).Elem())
 
+	// Determine if handler wants a ProgressReporter or a ToolStream as its
+	// trailing parameter - mutually exclusive, since a streaming handler
+	// reports progress through its ToolStream instead
+	hasReporter := numIn > 0 && handlerType.In(numIn-1) == progressReporterType
+	hasStream := numIn > 0 && handlerType.In(numIn-1) == toolStreamType
+	if hasReporter || hasStream {
+		numIn--
+	}
+
 	var argIndex int
 	if hasContext {
 		argIndex = 1
@@ -382,7 +692,7 @@ func (s *MCPServer) callToolHandler(handler interface{}, arguments interface{})
 
 	// If no args expected, call with no args
 	if numIn == argIndex {
-		return s.invokeHandler(handlerValue, hasContext, reflect.Value{})
+		return s.invokeHandler(ctx, handlerValue, hasContext, reflect.Value{}, hasReporter, reporter, hasStream, stream)
 	}
 
 	// Marshal arguments to JSON then unmarshal to the expected type
@@ -400,21 +710,50 @@ func (s *MCPServer) callToolHandler(handler interface{}, arguments interface{})
 		}
 	}
 
-	return s.invokeHandler(handlerValue, hasContext, argValue.Elem())
+	return s.invokeHandler(ctx, handlerValue, hasContext, argValue.Elem(), hasReporter, reporter, hasStream, stream)
 }
 
-// invokeHandler invokes the handler function
-func (s *MCPServer) invokeHandler(handlerValue reflect.Value, hasContext bool, argValue reflect.Value) (*ToolResponse, error) {
+// invokeHandler invokes the handler function. ctx is the request's context,
+// threaded through unchanged so handlers that call back into the server
+// (e.g. RequestSampling) route to the same session the call arrived on. A
+// streaming handler (hasStream) returns a single error instead of a
+// (*ToolResponse, error) pair, since it pushes its results through stream
+// as it goes rather than returning them all at once.
+func (s *MCPServer) invokeHandler(ctx context.Context, handlerValue reflect.Value, hasContext bool, argValue reflect.Value, hasReporter bool, reporter ProgressReporter, hasStream bool, stream ToolStream) (*ToolResponse, error) {
 	var args []reflect.Value
 	if hasContext {
-		args = append(args, reflect.ValueOf(context.Background()))
+		args = append(args, reflect.ValueOf(ctx))
 	}
 	if argValue.IsValid() {
 		args = append(args, argValue)
 	}
+	if hasReporter {
+		if reporter == nil {
+			reporter = func(float64, *float64) error { return nil }
+		}
+		args = append(args, reflect.ValueOf(reporter))
+	}
+	if hasStream {
+		if stream == nil {
+			stream = noopToolStream{}
+		}
+		args = append(args, reflect.ValueOf(stream))
+	}
 
 	results := handlerValue.Call(args)
 
+	if hasStream {
+		if len(results) != 1 {
+			return nil, fmt.Errorf("streaming tool handler must return a single error")
+		}
+		if !results[0].IsNil() {
+			return nil, results[0].Interface().(error)
+		}
+		// All content was already pushed via stream.SendPartial; the final
+		// response is just an ack that the call completed
+		return NewToolResponse(), nil
+	}
+
 	// Handle return values
 	if len(results) == 0 {
 		return NewToolResponse(), nil
@@ -506,6 +845,7 @@ func (s *MCPServer) handlePromptsList(ctx context.Context, params interface{}) (
 // handlePromptsGet handles the prompts/get request
 func (s *MCPServer) handlePromptsGet(ctx context.Context, params interface{}) (interface{}, error) {
 	log.Println("Handling prompts/get request")
+	ctx = s.withNegotiatedVersion(ctx)
 
 	// Parse params
 	paramsMap, ok := params.(map[string]interface{})
@@ -528,8 +868,12 @@ func (s *MCPServer) handlePromptsGet(ctx context.Context, params interface{}) (i
 
 	log.Printf("Getting prompt: %s with args: %v", promptName, arguments)
 
-	// Call the handler (similar to tools but returns PromptResponse)
-	result, err := s.callPromptHandler(prompt.handler, arguments)
+	// Call the handler (similar to tools but returns PromptResponse), routed
+	// through any registered prompt interceptors
+	final := func(ctx context.Context, name string, args interface{}) (*PromptResponse, error) {
+		return s.callPromptHandler(prompt.handler, args)
+	}
+	result, err := chainPromptCall(s.promptInterceptors, final)(ctx, promptName, arguments)
 	if err != nil {
 		return nil, fmt.Errorf("error calling prompt handler: %w", err)
 	}
@@ -607,6 +951,7 @@ func (s *MCPServer) handleResourcesList(ctx context.Context, params interface{})
 // handleResourceRead handles the resources/read request
 func (s *MCPServer) handleResourceRead(ctx context.Context, params interface{}) (interface{}, error) {
 	log.Println("Handling resources/read request")
+	ctx = s.withNegotiatedVersion(ctx)
 
 	// Parse params
 	paramsMap, ok := params.(map[string]interface{})
@@ -627,8 +972,11 @@ func (s *MCPServer) handleResourceRead(ctx context.Context, params interface{})
 
 	log.Printf("Reading resource: %s (%s)", resource.name, uri)
 
-	// Call the handler
-	result, err := s.callResourceHandler(resource.handler)
+	// Call the handler, routed through any registered resource interceptors
+	final := func(ctx context.Context, uri string) (*ResourceResponse, error) {
+		return s.callResourceHandler(resource.handler)
+	}
+	result, err := chainResourceCall(s.resourceInterceptors, final)(ctx, uri)
 	if err != nil {
 		return nil, fmt.Errorf("error calling resource handler: %w", err)
 	}
@@ -716,6 +1064,27 @@ func (s *MCPServer) handlePing(ctx context.Context, params interface{}) (interfa
 	return map[string]interface{}{}, nil
 }
 
+// RequestSampling asks the connected client to run its LLM over req.Messages,
+// e.g. from within a tool handler that needs a completion. ctx must be (or
+// be derived from) the context passed into the tool handler, since that's
+// what carries the session the request arrived on for transports that
+// multiplex several clients (SSE, streamable HTTP); passing an unrelated
+// context will route the request to the wrong client, if it can be routed
+// at all.
+func (s *MCPServer) RequestSampling(ctx context.Context, req SamplingRequest) (*SamplingResponse, error) {
+	result, err := s.protocol.Request(ctx, "sampling/createMessage", req)
+	if err != nil {
+		return nil, fmt.Errorf("sampling/createMessage request failed: %w", err)
+	}
+
+	var resp SamplingResponse
+	if err := unmarshalResponse(result, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse sampling/createMessage response: %w", err)
+	}
+
+	return &resp, nil
+}
+
 // DeregisterTool removes a tool from the server
 func (s *MCPServer) DeregisterTool(name string) error {
 	s.mu.Lock()
@@ -757,14 +1126,21 @@ func (s *MCPServer) DeregisterPrompt(name string) error {
 // DeregisterResource removes a resource from the server
 func (s *MCPServer) DeregisterResource(uri string) error {
 	s.mu.Lock()
-	if _, exists := s.resources[uri]; !exists {
+	resource, exists := s.resources[uri]
+	if !exists {
 		s.mu.Unlock()
 		return fmt.Errorf("resource not found: %s", uri)
 	}
+	if resource.watchCancel != nil {
+		resource.watchCancel()
+		s.resourceWatcherCount--
+	}
 
 	delete(s.resources, uri)
 	s.mu.Unlock()
 
+	s.resourceHub.removeURI(uri)
+
 	log.Printf("Deregistered resource: %s", uri)
 
 	// Send change notification (after releasing lock to avoid deadlock)
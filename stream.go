@@ -0,0 +1,65 @@
+package mcpgo
+
+import "github.com/DR1N0/mcp-go/protocol"
+
+// ToolStream lets a streaming tool handler push incremental results back to
+// the caller before returning its final error. Handlers opt in by taking a
+// ToolStream as their last argument, in place of a ProgressReporter; the
+// server substitutes a no-op implementation when the caller didn't request
+// progress tracking (and so has no progress token to correlate messages
+// back to this call).
+type ToolStream interface {
+	// SendPartial pushes incremental content to the caller as a
+	// notifications/tools/partial message.
+	SendPartial(content ...Content) error
+
+	// SendProgress reports progress on the call, like a ProgressReporter,
+	// additionally carrying a human-readable status message.
+	SendProgress(pct float64, msg string) error
+}
+
+// ToolPartialParams carries the payload of a notifications/tools/partial
+// message: incremental content for the tools/call identified by
+// ProgressToken.
+type ToolPartialParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Content       []Content   `json:"content"`
+}
+
+// StreamProgressParams is ProgressParams's counterpart for a ToolStream,
+// additionally carrying a human-readable status message.
+type StreamProgressParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Message       string      `json:"message,omitempty"`
+}
+
+// toolStream is the concrete ToolStream handed to a streaming tool handler,
+// tagging every message it sends with the progress token the caller
+// supplied in the request's _meta field.
+type toolStream struct {
+	protocol protocol.Protocol
+	token    interface{}
+}
+
+func (t *toolStream) SendPartial(content ...Content) error {
+	return t.protocol.Notification("notifications/tools/partial", ToolPartialParams{
+		ProgressToken: t.token,
+		Content:       content,
+	})
+}
+
+func (t *toolStream) SendProgress(pct float64, msg string) error {
+	return t.protocol.Notification("notifications/progress", StreamProgressParams{
+		ProgressToken: t.token,
+		Progress:      pct,
+		Message:       msg,
+	})
+}
+
+// noopToolStream is handed to a streaming tool handler when the caller
+// didn't supply a progress token, so there's nowhere to route messages to.
+type noopToolStream struct{}
+
+func (noopToolStream) SendPartial(content ...Content) error   { return nil }
+func (noopToolStream) SendProgress(pct float64, msg string) error { return nil }
@@ -0,0 +1,267 @@
+package mcpgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// resourceUpdateQueueLen bounds how many pending update notifications a
+// single subscriber can accumulate before ResourceUpdatePolicy kicks in.
+const resourceUpdateQueueLen = 16
+
+// ResourceWatcher observes a resource for changes and reports them back
+// through onUpdate, so the server can push notifications/resources/updated
+// to subscribed sessions instead of clients having to poll resources/read.
+// Watch should block until ctx is canceled, at which point it should stop
+// watching and return nil.
+type ResourceWatcher interface {
+	Watch(ctx context.Context, uri string, onUpdate func()) error
+}
+
+// ResourceUpdatePolicy controls what happens when a subscribed session's
+// update queue is already full when another update arrives.
+type ResourceUpdatePolicy int
+
+const (
+	// DropOldestOnFull discards the oldest undelivered update to make room
+	// for the new one, so NotifyResourceUpdated never blocks its caller.
+	DropOldestOnFull ResourceUpdatePolicy = iota
+	// BlockOnFull waits for the subscriber to catch up before accepting
+	// another update, applying backpressure to NotifyResourceUpdated.
+	BlockOnFull
+)
+
+// ResourceOption configures a resource at RegisterResource time.
+type ResourceOption func(*registeredResource)
+
+// WithResourceWatcher installs w to back resources/subscribe for this
+// resource: the server starts w.Watch in a background goroutine and calls
+// NotifyResourceUpdated(uri) for every change it reports.
+func WithResourceWatcher(w ResourceWatcher) ResourceOption {
+	return func(r *registeredResource) {
+		r.watcher = w
+	}
+}
+
+// resourceHub fans out resource update notifications to the sessions
+// subscribed to each URI, one bounded queue and drain goroutine per
+// subscription so a slow session can't stall the others.
+type resourceHub struct {
+	mu     sync.Mutex
+	subs   map[string]map[string]*resourceSub // uri -> sessionID -> sub
+	policy ResourceUpdatePolicy
+	notify func(sessionID, uri string) error
+	closed bool
+}
+
+// resourceSub is one session's subscription to one URI.
+type resourceSub struct {
+	cancel  context.CancelFunc
+	updates chan struct{}
+}
+
+// newResourceHub creates a resourceHub that delivers updates via notify,
+// applying policy when a subscriber's queue is full.
+func newResourceHub(policy ResourceUpdatePolicy, notify func(sessionID, uri string) error) *resourceHub {
+	return &resourceHub{
+		subs:   make(map[string]map[string]*resourceSub),
+		policy: policy,
+		notify: notify,
+	}
+}
+
+// subscribe registers sessionID for update notifications on uri,
+// replacing any existing subscription for the same session and URI.
+func (h *resourceHub) subscribe(sessionID, uri string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	if byURI, ok := h.subs[uri]; ok {
+		if existing, ok := byURI[sessionID]; ok {
+			existing.cancel()
+		}
+	} else {
+		h.subs[uri] = make(map[string]*resourceSub)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &resourceSub{
+		cancel:  cancel,
+		updates: make(chan struct{}, resourceUpdateQueueLen),
+	}
+	h.subs[uri][sessionID] = sub
+	go h.drain(ctx, sessionID, uri, sub)
+}
+
+// unsubscribe removes sessionID's subscription to uri, if any.
+func (h *resourceHub) unsubscribe(sessionID, uri string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	byURI, ok := h.subs[uri]
+	if !ok {
+		return
+	}
+	if sub, ok := byURI[sessionID]; ok {
+		sub.cancel()
+		delete(byURI, sessionID)
+	}
+	if len(byURI) == 0 {
+		delete(h.subs, uri)
+	}
+}
+
+// removeURI cancels every subscription to uri, e.g. when the resource is
+// deregistered.
+func (h *resourceHub) removeURI(uri string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, sub := range h.subs[uri] {
+		sub.cancel()
+	}
+	delete(h.subs, uri)
+}
+
+// notifyUpdated queues an update for every session subscribed to uri,
+// applying h.policy if a subscriber's queue is already full.
+func (h *resourceHub) notifyUpdated(uri string) {
+	h.mu.Lock()
+	subs := make([]*resourceSub, 0, len(h.subs[uri]))
+	for _, sub := range h.subs[uri] {
+		subs = append(subs, sub)
+	}
+	h.mu.Unlock()
+
+	for _, sub := range subs {
+		switch h.policy {
+		case BlockOnFull:
+			sub.updates <- struct{}{}
+		default: // DropOldestOnFull
+			select {
+			case sub.updates <- struct{}{}:
+			default:
+				select {
+				case <-sub.updates:
+				default:
+				}
+				select {
+				case sub.updates <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// drain delivers queued updates for one subscription until ctx is
+// canceled by unsubscribe, removeURI, or close.
+func (h *resourceHub) drain(ctx context.Context, sessionID, uri string, sub *resourceSub) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.updates:
+			if err := h.notify(sessionID, uri); err != nil {
+				log.Printf("failed to notify session %s of update to %s: %v", sessionID, uri, err)
+			}
+		}
+	}
+}
+
+// close cancels every subscription, stopping all drain goroutines.
+func (h *resourceHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, byURI := range h.subs {
+		for _, sub := range byURI {
+			sub.cancel()
+		}
+	}
+	h.subs = make(map[string]map[string]*resourceSub)
+}
+
+// notifySession sends a notifications/resources/updated message for uri
+// directly to sessionID, bypassing protocol.Notification (which always
+// broadcasts) the same way grpcServerTransport.SendTo targets a single
+// session: by carrying the session ID on the context passed to Send.
+func (s *MCPServer) notifySession(sessionID, uri string) error {
+	params, err := json.Marshal(map[string]interface{}{"uri": uri})
+	if err != nil {
+		return fmt.Errorf("failed to marshal resources/updated params: %w", err)
+	}
+	msg := &BaseJSONRPCMessage{
+		JSONRPC: "2.0",
+		Method:  "notifications/resources/updated",
+		Params:  params,
+	}
+	ctx := context.WithValue(context.Background(), "session_id", sessionID)
+	return s.transport.Send(ctx, msg)
+}
+
+// handleResourcesSubscribe handles the resources/subscribe request
+func (s *MCPServer) handleResourcesSubscribe(ctx context.Context, params interface{}) (interface{}, error) {
+	log.Println("Handling resources/subscribe request")
+
+	paramsMap, ok := params.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid params type")
+	}
+	uri, ok := paramsMap["uri"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing resource URI")
+	}
+
+	sessionID, ok := ctx.Value("session_id").(string)
+	if !ok || sessionID == "" {
+		return nil, fmt.Errorf("resources/subscribe requires a session-aware transport")
+	}
+
+	s.mu.RLock()
+	_, exists := s.resources[uri]
+	s.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("unknown resource: %s", uri)
+	}
+
+	s.resourceHub.subscribe(sessionID, uri)
+
+	return map[string]interface{}{}, nil
+}
+
+// handleResourcesUnsubscribe handles the resources/unsubscribe request
+func (s *MCPServer) handleResourcesUnsubscribe(ctx context.Context, params interface{}) (interface{}, error) {
+	log.Println("Handling resources/unsubscribe request")
+
+	paramsMap, ok := params.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid params type")
+	}
+	uri, ok := paramsMap["uri"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing resource URI")
+	}
+
+	sessionID, ok := ctx.Value("session_id").(string)
+	if !ok || sessionID == "" {
+		return nil, fmt.Errorf("resources/unsubscribe requires a session-aware transport")
+	}
+
+	s.resourceHub.unsubscribe(sessionID, uri)
+
+	return map[string]interface{}{}, nil
+}
+
+// NotifyResourceUpdated sends a notifications/resources/updated message
+// for uri to every session currently subscribed to it.
+func (s *MCPServer) NotifyResourceUpdated(uri string) {
+	s.resourceHub.notifyUpdated(uri)
+}
@@ -0,0 +1,157 @@
+package mcpgo
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResourceHub_SubscribeUnsubscribeIdempotent(t *testing.T) {
+	var mu sync.Mutex
+	var delivered []string
+	notify := func(sessionID, uri string) error {
+		mu.Lock()
+		delivered = append(delivered, sessionID+":"+uri)
+		mu.Unlock()
+		return nil
+	}
+
+	h := newResourceHub(DropOldestOnFull, notify)
+	h.subscribe("s1", "file:///a")
+	h.unsubscribe("s1", "file:///a")
+	h.unsubscribe("s1", "file:///a") // must not panic on a repeat unsubscribe
+
+	h.notifyUpdated("file:///a")
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(delivered) != 0 {
+		t.Fatalf("expected no delivery after unsubscribe, got %v", delivered)
+	}
+}
+
+func TestResourceHub_FanOutToMultipleSessions(t *testing.T) {
+	var mu sync.Mutex
+	delivered := make(map[string]int)
+	done := make(chan struct{}, 2)
+	notify := func(sessionID, uri string) error {
+		mu.Lock()
+		delivered[sessionID]++
+		mu.Unlock()
+		done <- struct{}{}
+		return nil
+	}
+
+	h := newResourceHub(DropOldestOnFull, notify)
+	h.subscribe("s1", "file:///a")
+	h.subscribe("s2", "file:///a")
+
+	h.notifyUpdated("file:///a")
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for delivery %d", i)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if delivered["s1"] != 1 || delivered["s2"] != 1 {
+		t.Fatalf("expected both sessions notified once, got %v", delivered)
+	}
+}
+
+func TestResourceHub_SlowConsumerBackpressureDropsOldest(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var calls int
+	notify := func(sessionID, uri string) error {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			close(entered)
+			<-release
+		}
+		return nil
+	}
+
+	h := newResourceHub(DropOldestOnFull, notify)
+	h.subscribe("s1", "file:///a")
+
+	h.notifyUpdated("file:///a") // picked up immediately, blocks notify until release
+	<-entered
+
+	// Queue capacity is resourceUpdateQueueLen; flood past it to exercise
+	// the drop-oldest path without NotifyResourceUpdated ever blocking.
+	for i := 0; i < resourceUpdateQueueLen+5; i++ {
+		h.notifyUpdated("file:///a")
+	}
+	close(release)
+
+	deadline := time.After(time.Second)
+	for {
+		mu.Lock()
+		n := calls
+		mu.Unlock()
+		if n > 1 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("expected queued updates to still be delivered after the flood")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestResourceHub_BlockOnFullAppliesBackpressure(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var calls int
+	notify := func(sessionID, uri string) error {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			close(entered)
+			<-release
+		}
+		return nil
+	}
+
+	h := newResourceHub(BlockOnFull, notify)
+	h.subscribe("s1", "file:///a")
+
+	h.notifyUpdated("file:///a")
+	<-entered
+
+	for i := 0; i < resourceUpdateQueueLen; i++ {
+		h.notifyUpdated("file:///a")
+	}
+
+	filled := make(chan struct{})
+	go func() {
+		h.notifyUpdated("file:///a") // queue is now full; must block until release
+		close(filled)
+	}()
+
+	select {
+	case <-filled:
+		t.Fatal("expected notifyUpdated to block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-filled:
+	case <-time.After(time.Second):
+		t.Fatal("expected notifyUpdated to unblock once the consumer drained")
+	}
+}
@@ -0,0 +1,131 @@
+package mcpgo_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	mcpgo "github.com/DR1N0/mcp-go"
+)
+
+func TestContent_TextRoundTrip(t *testing.T) {
+	resp := mcpgo.NewToolResponse(mcpgo.NewTextContent("hello"))
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded mcpgo.ToolResponse
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(decoded.Content) != 1 || decoded.Content[0].Type != "text" || decoded.Content[0].Text == nil || *decoded.Content[0].Text != "hello" {
+		t.Errorf("Unexpected round-tripped content: %+v", decoded.Content)
+	}
+}
+
+func TestContent_ImageRoundTrip(t *testing.T) {
+	raw := []byte{0x89, 0x50, 0x4e, 0x47}
+	resp := mcpgo.NewToolResponse(mcpgo.NewImageContent(raw, "image/png"))
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded mcpgo.ToolResponse
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	content := decoded.Content[0]
+	if content.Type != "image" || content.MimeType == nil || *content.MimeType != "image/png" {
+		t.Fatalf("Unexpected round-tripped content: %+v", content)
+	}
+
+	decodedData, err := base64.StdEncoding.DecodeString(*content.Data)
+	if err != nil {
+		t.Fatalf("Failed to decode base64 data: %v", err)
+	}
+	if string(decodedData) != string(raw) {
+		t.Errorf("Expected decoded data %v, got %v", raw, decodedData)
+	}
+}
+
+func TestContent_AudioRoundTrip(t *testing.T) {
+	raw := []byte("fake-audio-bytes")
+	content := mcpgo.NewAudioContent(raw, "audio/wav")
+
+	if content.Type != "audio" {
+		t.Errorf("Expected type 'audio', got %q", content.Type)
+	}
+	if err := content.Validate(); err != nil {
+		t.Errorf("Expected valid audio content, got error: %v", err)
+	}
+
+	data, err := json.Marshal(mcpgo.NewToolResponse(content))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded mcpgo.ToolResponse
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	got := decoded.Content[0]
+	if got.Type != "audio" || got.MimeType == nil || *got.MimeType != "audio/wav" || got.Data == nil || *got.Data != base64.StdEncoding.EncodeToString(raw) {
+		t.Errorf("Unexpected round-tripped content: %+v", got)
+	}
+}
+
+func TestContent_EmbeddedResourceRoundTrip(t *testing.T) {
+	resource := mcpgo.NewTextResource("file:///a.txt", "contents", "text/plain")
+	msg := mcpgo.NewPromptMessage(mcpgo.NewEmbeddedResource(resource), mcpgo.RoleUser)
+	resp := mcpgo.NewPromptResponse("", msg)
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded mcpgo.PromptResponse
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	content := decoded.Messages[0].Content
+	if content.Type != "resource" || content.Resource == nil || content.Resource.URI != "file:///a.txt" {
+		t.Errorf("Unexpected round-tripped content: %+v", content)
+	}
+}
+
+func TestContent_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content mcpgo.Content
+		wantErr bool
+	}{
+		{"valid text", *mcpgo.NewTextContent("hi"), false},
+		{"text missing Text", mcpgo.Content{Type: "text"}, true},
+		{"valid image", *mcpgo.NewImageContent([]byte("x"), "image/png"), false},
+		{"image missing data", mcpgo.Content{Type: "image", MimeType: strPtr("image/png")}, true},
+		{"image missing mimeType", mcpgo.Content{Type: "image", Data: strPtr("abc")}, true},
+		{"valid resource", *mcpgo.NewEmbeddedResource(mcpgo.NewTextResource("uri", "text", "text/plain")), false},
+		{"resource missing Resource", mcpgo.Content{Type: "resource"}, true},
+		{"unknown type", mcpgo.Content{Type: "bogus"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.content.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,149 @@
+package transport
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIPConfig configures how an HTTP-based transport resolves the real
+// client address for deployments behind a reverse proxy (nginx, Caddy,
+// Apache, Cloudflare, ...). The zero value trusts nothing: the immediate
+// peer address is always used as-is, and forwarding headers are ignored.
+type ClientIPConfig struct {
+	// TrustedProxies lists the address ranges allowed to report a client IP
+	// via ForwardedHeader. Headers from any other peer are ignored.
+	TrustedProxies []netip.Prefix
+	// ForwardedHeader selects which header to trust: "X-Forwarded-For",
+	// "X-Real-IP", or "Forwarded". Defaults to "X-Forwarded-For".
+	ForwardedHeader string
+	// TrustHops caps how many proxy hops are skipped while walking
+	// ForwardedHeader from the nearest peer backwards. Zero means unlimited:
+	// keep walking until an untrusted address is found.
+	TrustHops int
+}
+
+// ResolveClientIP determines the real client address for an HTTP request
+// with the given RemoteAddr and headers, per cfg. It walks the forwarding
+// header from right to left (nearest hop first), skipping entries whose
+// source is a trusted proxy, and stops at the first untrusted address -
+// that's the real client. Forwarding headers are only consulted when the
+// immediate peer is itself trusted; an untrusted peer's headers are never
+// honored, since it could otherwise spoof its client IP.
+func ResolveClientIP(remoteAddr string, header http.Header, cfg ClientIPConfig) (netip.Addr, bool) {
+	peer, ok := hostAddr(remoteAddr)
+	if !ok {
+		return netip.Addr{}, false
+	}
+
+	if !isTrustedAddr(peer, cfg.TrustedProxies) {
+		return peer, true
+	}
+
+	switch cfg.ForwardedHeader {
+	case "X-Real-IP":
+		if addr, err := netip.ParseAddr(strings.TrimSpace(header.Get("X-Real-IP"))); err == nil {
+			return addr, true
+		}
+		return peer, true
+	case "Forwarded":
+		return walkChain(parseForwarded(header.Values("Forwarded")), peer, cfg)
+	default:
+		return walkChain(parseXFF(header.Get("X-Forwarded-For")), peer, cfg)
+	}
+}
+
+// walkChain applies the right-to-left trusted-hop walk described on
+// ResolveClientIP to an already-parsed forwarding chain
+func walkChain(chain []netip.Addr, peer netip.Addr, cfg ClientIPConfig) (netip.Addr, bool) {
+	if len(chain) == 0 {
+		return peer, true
+	}
+
+	hops := 0
+	for i := len(chain) - 1; i >= 0; i-- {
+		addr := chain[i]
+		if cfg.TrustHops > 0 && hops >= cfg.TrustHops {
+			return addr, true
+		}
+		if !isTrustedAddr(addr, cfg.TrustedProxies) {
+			return addr, true
+		}
+		hops++
+	}
+
+	// Every hop in the chain was a trusted proxy; the leftmost entry is the
+	// original client that the first proxy in the path recorded.
+	return chain[0], true
+}
+
+// isTrustedAddr reports whether addr falls within any of the trusted prefixes
+func isTrustedAddr(addr netip.Addr, trusted []netip.Prefix) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostAddr extracts the netip.Addr from a "host:port" remote address,
+// falling back to parsing it as a bare address
+func hostAddr(remoteAddr string) (netip.Addr, bool) {
+	host := remoteAddr
+	if h, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr, true
+}
+
+// parseXFF parses a comma-separated X-Forwarded-For header value into an
+// ordered chain (leftmost = original client), skipping unparsable entries
+func parseXFF(value string) []netip.Addr {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	chain := make([]netip.Addr, 0, len(parts))
+	for _, part := range parts {
+		if addr, ok := hostAddr(strings.TrimSpace(part)); ok {
+			chain = append(chain, addr)
+		}
+	}
+	return chain
+}
+
+// parseForwarded extracts the for= parameter from each element of one or
+// more RFC 7239 Forwarded header values into an ordered chain
+func parseForwarded(values []string) []netip.Addr {
+	var chain []netip.Addr
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			for _, param := range strings.Split(element, ";") {
+				name, val, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(name), "for") {
+					continue
+				}
+				val = strings.Trim(strings.TrimSpace(val), `"`)
+				val = strings.TrimPrefix(val, "[")
+				if idx := strings.LastIndex(val, "]"); idx != -1 {
+					val = val[:idx]
+				} else if host, _, err := net.SplitHostPort(val); err == nil {
+					val = host
+				}
+				if addr, err := netip.ParseAddr(val); err == nil {
+					chain = append(chain, addr)
+				}
+			}
+		}
+	}
+	return chain
+}
@@ -0,0 +1,178 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Principal identifies the caller an Authenticator resolved a token to.
+type Principal struct {
+	// Subject is the authenticated identity, e.g. a user or service
+	// account ID. It's opaque to AuthInterceptor.
+	Subject string
+	// Claims carries authenticator-specific data. The key "clientName",
+	// if present, binds this Principal to a single MCP clientInfo.name:
+	// a stream's "initialize" request is rejected if it names a
+	// different client.
+	Claims map[string]interface{}
+}
+
+// Authenticator resolves a bearer token or API key, extracted from gRPC
+// metadata by AuthInterceptor, to the Principal it authenticates. It
+// should return an error for any token it can't verify.
+type Authenticator func(ctx context.Context, token string) (Principal, error)
+
+type principalContextKey struct{}
+
+// PrincipalFromContext returns the Principal AuthInterceptor authenticated
+// for the current call or stream, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(Principal)
+	return p, ok
+}
+
+// authInterceptor authenticates unary calls and streams against an
+// Authenticator, extracting a bearer token from the "authorization"
+// metadata header (or an API key from "x-api-key"), and binds the
+// resulting Principal to the MCP initialize handshake on streams.
+type authInterceptor struct {
+	authenticate Authenticator
+	requireAuth  bool
+}
+
+// tokenFromMetadata extracts a bearer token or API key from ctx's incoming
+// gRPC metadata, preferring "authorization" over "x-api-key".
+func tokenFromMetadata(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	if vals := md.Get("authorization"); len(vals) > 0 {
+		token := vals[0]
+		if strings.HasPrefix(token, "Bearer ") {
+			token = strings.TrimPrefix(token, "Bearer ")
+		}
+		return token, true
+	}
+	if vals := md.Get("x-api-key"); len(vals) > 0 {
+		return vals[0], true
+	}
+	return "", false
+}
+
+// authenticateContext extracts and verifies ctx's credentials. It returns
+// a nil Principal and nil error when no credentials were presented and
+// a.requireAuth is unset, an Unauthenticated status error when none were
+// presented and it is set, and an Unauthenticated status error when the
+// credentials presented were rejected by a.authenticate.
+func (a *authInterceptor) authenticateContext(ctx context.Context) (*Principal, error) {
+	token, ok := tokenFromMetadata(ctx)
+	if !ok {
+		if a.requireAuth {
+			return nil, status.Error(codes.Unauthenticated, "no credentials provided")
+		}
+		return nil, nil
+	}
+	principal, err := a.authenticate(ctx, token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials: %v", err)
+	}
+	return &principal, nil
+}
+
+// Unary authenticates a unary RPC (e.g. the grpc.health.v1 Check call),
+// making the resulting Principal available via PrincipalFromContext.
+func (a *authInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		principal, err := a.authenticateContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if principal != nil {
+			ctx = context.WithValue(ctx, principalContextKey{}, *principal)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream authenticates the Transport stream before its first Recv,
+// rejecting it with codes.Unauthenticated if authentication fails, then
+// binds the resulting Principal to the clientInfo presented in the
+// stream's "initialize" request: a mismatch closes the stream with
+// codes.PermissionDenied.
+func (a *authInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		principal, err := a.authenticateContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		if principal == nil {
+			return handler(srv, ss)
+		}
+		wrapped := &authServerStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), principalContextKey{}, *principal),
+			principal:    *principal,
+		}
+		return handler(srv, wrapped)
+	}
+}
+
+// authServerStream wraps a grpc.ServerStream to carry the authenticated
+// Principal in its Context and to enforce the clientInfo/Principal
+// binding on the stream's "initialize" message.
+type authServerStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	principal Principal
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	msg, ok := m.(*pb.JSONRPCMessage)
+	if !ok || msg.Method != "initialize" || msg.Params == nil {
+		return nil
+	}
+	clientName, err := clientInfoName(msg.Params)
+	if err != nil || clientName == "" {
+		return nil
+	}
+	if expected, ok := s.principal.Claims["clientName"].(string); ok && expected != clientName {
+		return status.Errorf(codes.PermissionDenied, "initialize clientInfo %q does not match authenticated principal", clientName)
+	}
+	return nil
+}
+
+// clientInfoName extracts clientInfo.name from an "initialize" request's
+// params.
+func clientInfoName(params *structpb.Value) (string, error) {
+	raw, err := protojson.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal initialize params: %w", err)
+	}
+	var parsed struct {
+		ClientInfo struct {
+			Name string `json:"name"`
+		} `json:"clientInfo"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse initialize params: %w", err)
+	}
+	return parsed.ClientInfo.Name, nil
+}
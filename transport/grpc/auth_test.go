@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestTokenFromMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		want   string
+		wantOk bool
+	}{
+		{"bearer", metadata.Pairs("authorization", "Bearer abc123"), "abc123", true},
+		{"raw authorization", metadata.Pairs("authorization", "abc123"), "abc123", true},
+		{"api key", metadata.Pairs("x-api-key", "xyz789"), "xyz789", true},
+		{"none", metadata.MD{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			got, ok := tokenFromMetadata(ctx)
+			if ok != tt.wantOk || got != tt.want {
+				t.Fatalf("tokenFromMetadata() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_AuthenticateContext(t *testing.T) {
+	a := &authInterceptor{
+		authenticate: func(ctx context.Context, token string) (Principal, error) {
+			if token != "good" {
+				return Principal{}, status.Error(codes.Unauthenticated, "bad token")
+			}
+			return Principal{Subject: "alice"}, nil
+		},
+		requireAuth: true,
+	}
+
+	if _, err := a.authenticateContext(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated with no credentials, got %v", err)
+	}
+
+	badCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bad"))
+	if _, err := a.authenticateContext(badCtx); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated with rejected token, got %v", err)
+	}
+
+	goodCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer good"))
+	principal, err := a.authenticateContext(goodCtx)
+	if err != nil {
+		t.Fatalf("authenticateContext failed: %v", err)
+	}
+	if principal == nil || principal.Subject != "alice" {
+		t.Fatalf("expected principal alice, got %+v", principal)
+	}
+}
+
+func TestAuthInterceptor_AuthenticateContext_OptionalWithoutCredentials(t *testing.T) {
+	a := &authInterceptor{requireAuth: false}
+	principal, err := a.authenticateContext(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error when auth is optional, got %v", err)
+	}
+	if principal != nil {
+		t.Fatalf("expected nil principal without credentials, got %+v", principal)
+	}
+}
+
+// fakeRecvStream is a minimal grpc.ServerStream whose RecvMsg is a no-op,
+// so authServerStream.RecvMsg sees exactly the message the test pre-populates.
+type fakeRecvStream struct {
+	grpc.ServerStream
+}
+
+func (fakeRecvStream) RecvMsg(m interface{}) error { return nil }
+
+func TestAuthServerStream_RecvMsg_ClientInfoMismatchRejected(t *testing.T) {
+	params, err := structpb.NewValue(map[string]interface{}{
+		"clientInfo": map[string]interface{}{"name": "other-client"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build params: %v", err)
+	}
+
+	s := &authServerStream{
+		ServerStream: fakeRecvStream{},
+		ctx:          context.Background(),
+		principal:    Principal{Claims: map[string]interface{}{"clientName": "expected-client"}},
+	}
+
+	msg := &pb.JSONRPCMessage{Method: "initialize", Params: params}
+	err = s.RecvMsg(msg)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestAuthServerStream_RecvMsg_ClientInfoMatchAllowed(t *testing.T) {
+	params, err := structpb.NewValue(map[string]interface{}{
+		"clientInfo": map[string]interface{}{"name": "expected-client"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build params: %v", err)
+	}
+
+	s := &authServerStream{
+		ServerStream: fakeRecvStream{},
+		ctx:          context.Background(),
+		principal:    Principal{Claims: map[string]interface{}{"clientName": "expected-client"}},
+	}
+
+	msg := &pb.JSONRPCMessage{Method: "initialize", Params: params}
+	if err := s.RecvMsg(msg); err != nil {
+		t.Fatalf("expected matching clientInfo to be allowed, got %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package grpc
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"sync"
@@ -13,7 +12,7 @@ import (
 	"github.com/DR1N0/mcp-go/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/grpc/metadata"
 )
 
 // grpcClientTransport implements ClientTransport for gRPC
@@ -31,6 +30,7 @@ type grpcClientTransport struct {
 	cancel         context.CancelFunc
 	closed         bool
 	sendChan       chan *pb.JSONRPCMessage
+	sessionID      string
 }
 
 // NewClientTransport creates a new gRPC client transport
@@ -83,13 +83,33 @@ func (c *grpcClientTransport) Start(ctx context.Context) error {
 	c.client = pb.NewJSONRPCServiceClient(conn)
 	c.mu.Unlock()
 
+	// Present any session ID from a prior connection so the server can
+	// resume it rather than allocating a fresh one.
+	c.mu.RLock()
+	sessionID := c.sessionID
+	c.mu.RUnlock()
+	streamCtx := c.ctx
+	if sessionID != "" {
+		streamCtx = metadata.AppendToOutgoingContext(streamCtx, sessionIDMetadataKey, sessionID)
+	}
+
 	// Establish stream
-	stream, err := c.client.Transport(c.ctx)
+	stream, err := c.client.Transport(streamCtx)
 	if err != nil {
 		conn.Close()
 		return fmt.Errorf("failed to create stream: %w", err)
 	}
 
+	// The server echoes back the session ID (new or resumed) in the
+	// stream's response header; remember it for the next reconnect.
+	if header, err := stream.Header(); err == nil {
+		if vals := header.Get(sessionIDMetadataKey); len(vals) > 0 {
+			c.mu.Lock()
+			c.sessionID = vals[0]
+			c.mu.Unlock()
+		}
+	}
+
 	c.mu.Lock()
 	c.stream = stream
 	c.mu.Unlock()
@@ -263,120 +283,6 @@ func (c *grpcClientTransport) SetCloseHandler(handler func()) {
 	c.closeHandler = handler
 }
 
-// Helper functions (shared with server.go but repeated here for clarity)
-
-func protoToBaseClient(msg *pb.JSONRPCMessage) (*types.BaseJSONRPCMessage, error) {
-	base := &types.BaseJSONRPCMessage{
-		JSONRPC: msg.Jsonrpc,
-		Method:  msg.Method,
-	}
-
-	// Handle ID
-	switch id := msg.Id.(type) {
-	case *pb.JSONRPCMessage_IdString:
-		base.ID = id.IdString
-	case *pb.JSONRPCMessage_IdNumber:
-		base.ID = id.IdNumber
-	}
-
-	// Handle params
-	if msg.Params != nil {
-		paramsStruct, err := structpb.NewStruct(msg.Params.AsMap())
-		if err != nil {
-			return nil, fmt.Errorf("failed to create params struct: %w", err)
-		}
-		params, err := paramsStruct.MarshalJSON()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal params: %w", err)
-		}
-		base.Params = params
-	}
-
-	// Handle result
-	if msg.Result != nil {
-		resultStruct, err := structpb.NewStruct(msg.Result.AsMap())
-		if err != nil {
-			return nil, fmt.Errorf("failed to create result struct: %w", err)
-		}
-		result, err := resultStruct.MarshalJSON()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal result: %w", err)
-		}
-		base.Result = result
-	}
-
-	// Handle error
-	if msg.Error != nil {
-		base.Error = &types.RPCError{
-			Code:    int(msg.Error.Code),
-			Message: msg.Error.Message,
-		}
-		if msg.Error.Data != nil {
-			base.Error.Data = msg.Error.Data.AsMap()
-		}
-	}
-
-	return base, nil
-}
-
-func baseToProtoClient(msg *types.BaseJSONRPCMessage) (*pb.JSONRPCMessage, error) {
-	proto := &pb.JSONRPCMessage{
-		Jsonrpc: msg.JSONRPC,
-		Method:  msg.Method,
-	}
-
-	// Handle ID
-	switch id := msg.ID.(type) {
-	case string:
-		proto.Id = &pb.JSONRPCMessage_IdString{IdString: id}
-	case int:
-		proto.Id = &pb.JSONRPCMessage_IdNumber{IdNumber: int64(id)}
-	case int64:
-		proto.Id = &pb.JSONRPCMessage_IdNumber{IdNumber: id}
-	case float64:
-		proto.Id = &pb.JSONRPCMessage_IdNumber{IdNumber: int64(id)}
-	}
-
-	// Handle params
-	if len(msg.Params) > 0 {
-		var paramsMap map[string]interface{}
-		if err := json.Unmarshal(msg.Params, &paramsMap); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal params: %w", err)
-		}
-		params, err := structpb.NewStruct(paramsMap)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create params struct: %w", err)
-		}
-		proto.Params = params
-	}
-
-	// Handle result
-	if len(msg.Result) > 0 {
-		var resultMap map[string]interface{}
-		if err := json.Unmarshal(msg.Result, &resultMap); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal result: %w", err)
-		}
-		result, err := structpb.NewStruct(resultMap)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create result struct: %w", err)
-		}
-		proto.Result = result
-	}
-
-	// Handle error
-	if msg.Error != nil {
-		proto.Error = &pb.JSONRPCError{
-			Code:    int32(msg.Error.Code),
-			Message: msg.Error.Message,
-		}
-		if msg.Error.Data != nil {
-			data, err := structpb.NewStruct(msg.Error.Data.(map[string]interface{}))
-			if err != nil {
-				return nil, fmt.Errorf("failed to create error data struct: %w", err)
-			}
-			proto.Error.Data = data
-		}
-	}
-
-	return proto, nil
-}
+// Message conversion is shared with server.go: both transports use the same
+// protoToBase/baseToProto helpers, so there's exactly one place that knows
+// how a JSONRPCMessage maps to a types.BaseJSONRPCMessage.
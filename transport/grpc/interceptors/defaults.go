@@ -0,0 +1,57 @@
+package interceptors
+
+import (
+	"github.com/DR1N0/mcp-go/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+// Config selects which of the default chain's interceptors to install; a
+// zero-value field disables that concern. Install the chain with
+// WithDefaults, or call the individual constructors directly for finer
+// control over ordering or to mix in interceptors Config doesn't cover.
+type Config struct {
+	// Verify, if set, installs Auth/StreamAuth.
+	Verify      Verifier
+	RequireAuth bool
+	// RateLimiter, if set, installs RateLimit/StreamRateLimit.
+	RateLimiter *RateLimiter
+	// Recover, if set, installs Recovery/StreamRecovery.
+	Recover transport.ErrorHandler
+	// Log, if set, installs Logging/StreamLogging. Redactor may be nil.
+	Log      Logger
+	Redactor *Redactor
+	// Registerer, if set, installs Observability/StreamObservability
+	// backed by a Metrics registered with it.
+	Registerer prometheus.Registerer
+}
+
+// WithDefaults builds the unary and stream interceptor chains cfg selects,
+// in a fixed, sensible order: recovery first so a panic anywhere below it
+// is caught, then auth so later stages see an authenticated context, then
+// rate limiting so it rejects before paying for logging/metrics, then
+// logging and metrics last.
+func WithDefaults(cfg Config) (unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) {
+	if cfg.Recover != nil {
+		unary = append(unary, Recovery(cfg.Recover))
+		stream = append(stream, StreamRecovery(cfg.Recover))
+	}
+	if cfg.Verify != nil {
+		unary = append(unary, Auth(cfg.Verify, cfg.RequireAuth))
+		stream = append(stream, StreamAuth(cfg.Verify, cfg.RequireAuth))
+	}
+	if cfg.RateLimiter != nil {
+		unary = append(unary, RateLimit(cfg.RateLimiter))
+		stream = append(stream, StreamRateLimit(cfg.RateLimiter))
+	}
+	if cfg.Log != nil {
+		unary = append(unary, Logging(cfg.Log, cfg.Redactor))
+		stream = append(stream, StreamLogging(cfg.Log, cfg.Redactor))
+	}
+	if cfg.Registerer != nil {
+		metrics := NewMetrics(cfg.Registerer)
+		unary = append(unary, Observability(metrics))
+		stream = append(stream, StreamObservability(metrics))
+	}
+	return unary, stream
+}
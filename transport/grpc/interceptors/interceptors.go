@@ -0,0 +1,79 @@
+// Package interceptors provides reusable gRPC unary and stream interceptors
+// for the cross-cutting concerns typical MCP deployments need: auth, rate
+// limiting, panic recovery, logging, and metrics. Each concern is
+// independent and composable - install the ones you want individually via
+// grpc.ServerTransport's WithInterceptor/WithStreamInterceptor, or use
+// WithDefaults for a sensible chain.
+//
+// The MCP wire protocol runs over a single long-lived bidirectional stream
+// (see grpc.ServerTransport.Transport), so a plain grpc.StreamServerInterceptor
+// only fires once per connection rather than once per JSON-RPC call. To get
+// per-message coverage - rate limiting a method, tagging a metric with the
+// tool name from a tools/call payload - these interceptors wrap the
+// stream's RecvMsg, inspecting each JSON-RPC message as it arrives.
+package interceptors
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// jsonrpcMessage carries the fields these interceptors read from a JSON-RPC
+// request. It's decoded from pb.JSONRPCMessage.Params rather than the
+// protobuf message itself, so tool name extraction works the same way
+// regardless of how a given interceptor chain reached it.
+type jsonrpcMessage struct {
+	method string
+	tool   string
+	params json.RawMessage
+}
+
+// inspect extracts the method, and for a tools/call request the tool name,
+// from msg. It never fails: a message whose params don't parse as expected
+// simply yields an empty tool name, since interceptors should degrade
+// gracefully rather than break the stream over a field they don't need.
+func inspect(msg *pb.JSONRPCMessage) jsonrpcMessage {
+	m := jsonrpcMessage{method: msg.Method}
+	if msg.Params == nil {
+		return m
+	}
+	raw, err := protojson.Marshal(msg.Params)
+	if err != nil {
+		return m
+	}
+	m.params = raw
+	if msg.Method != "tools/call" {
+		return m
+	}
+	var call struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(raw, &call); err == nil {
+		m.tool = call.Name
+	}
+	return m
+}
+
+// metricLabel is the label value these interceptors use for a message's
+// "method" metric/log dimension: the tool name for tools/call, the bare
+// JSON-RPC method otherwise, matching observability.spanName's convention.
+func (m jsonrpcMessage) metricLabel() string {
+	if m.tool != "" {
+		return fmt.Sprintf("%s/%s", m.method, m.tool)
+	}
+	return m.method
+}
+
+// jsonrpcMessageFromAny inspects m, returning ok=false for anything that
+// isn't a *pb.JSONRPCMessage (e.g. a health check riding the same
+// connection) rather than failing the RecvMsg it came from.
+func jsonrpcMessageFromAny(m interface{}) (jsonrpcMessage, bool) {
+	msg, ok := m.(*pb.JSONRPCMessage)
+	if !ok {
+		return jsonrpcMessage{}, false
+	}
+	return inspect(msg), true
+}
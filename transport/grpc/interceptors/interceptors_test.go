@@ -0,0 +1,106 @@
+package interceptors
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestTokenFromMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		want   string
+		wantOk bool
+	}{
+		{"bearer", metadata.Pairs("authorization", "Bearer abc123"), "abc123", true},
+		{"raw authorization", metadata.Pairs("authorization", "abc123"), "abc123", true},
+		{"api key", metadata.Pairs("x-api-key", "xyz789"), "xyz789", true},
+		{"none", metadata.MD{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			got, ok := tokenFromMetadata(ctx)
+			if ok != tt.wantOk || got != tt.want {
+				t.Fatalf("tokenFromMetadata() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_RequireAuthWithoutCredentials(t *testing.T) {
+	_, err := authenticate(context.Background(), func(ctx context.Context, token string) (Principal, error) {
+		return Principal{}, nil
+	}, true)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestAuthenticate_OptionalWithoutCredentials(t *testing.T) {
+	principal, err := authenticate(context.Background(), func(ctx context.Context, token string) (Principal, error) {
+		t.Fatalf("verifier should not be called without credentials")
+		return Principal{}, nil
+	}, false)
+	if err != nil || principal != nil {
+		t.Fatalf("expected (nil, nil), got (%+v, %v)", principal, err)
+	}
+}
+
+func TestInspect_ExtractsToolName(t *testing.T) {
+	params, err := structpb.NewValue(map[string]interface{}{
+		"name":      "get_weather",
+		"arguments": map[string]interface{}{"city": "Berlin"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build params: %v", err)
+	}
+
+	msg := inspect(&pb.JSONRPCMessage{Method: "tools/call", Params: params})
+	if msg.tool != "get_weather" {
+		t.Fatalf("expected tool %q, got %q", "get_weather", msg.tool)
+	}
+	if got := msg.metricLabel(); got != "tools/call/get_weather" {
+		t.Fatalf("expected label %q, got %q", "tools/call/get_weather", got)
+	}
+}
+
+func TestRateLimiter_AllowsBurstThenRejects(t *testing.T) {
+	l := NewRateLimiter(0, 2)
+	if !l.allow("tools/call") || !l.allow("tools/call") {
+		t.Fatalf("expected the configured burst to be allowed")
+	}
+	if l.allow("tools/call") {
+		t.Fatalf("expected a request beyond the burst to be rejected")
+	}
+	if !l.allow("other/method") {
+		t.Fatalf("expected a distinct key to have its own budget")
+	}
+}
+
+func TestRedactor_MasksTaggedFields(t *testing.T) {
+	type getWeatherArgs struct {
+		City   string `json:"city" jsonschema:"required"`
+		APIKey string `json:"apiKey" jsonschema:"required,sensitive"`
+	}
+
+	r := NewRedactor()
+	r.Register("get_weather", reflect.TypeOf(getWeatherArgs{}), "sensitive")
+
+	out := r.redact("get_weather", []byte(`{"city":"Berlin","apiKey":"topsecret"}`))
+	if string(out) != `{"apiKey":"[REDACTED]","city":"Berlin"}` {
+		t.Fatalf("expected apiKey redacted, got %s", out)
+	}
+
+	unchanged := r.redact("other_tool", []byte(`{"apiKey":"topsecret"}`))
+	if string(unchanged) != `{"apiKey":"topsecret"}` {
+		t.Fatalf("expected unregistered tool to pass through unchanged, got %s", unchanged)
+	}
+}
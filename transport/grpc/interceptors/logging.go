@@ -0,0 +1,170 @@
+package interceptors
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// LogEntry is one request/response logged by Logging/StreamLogging.
+type LogEntry struct {
+	// Method is the bare JSON-RPC method, e.g. "tools/call".
+	Method string
+	// Tool is the tool name for a "tools/call" request, empty otherwise.
+	Tool string
+	// Arguments is the request's params, with any field named by a
+	// Redactor replaced with "[REDACTED]". Nil for a message with no
+	// params.
+	Arguments json.RawMessage
+	// Err is the error the call/message was resolved with, if any. Not
+	// populated on the request-side log entry for a stream message, since
+	// a JSON-RPC response is a separate message with no causal link
+	// logging can recover without correlating by request ID.
+	Err error
+}
+
+// Logger receives one LogEntry per logged request or stream message.
+type Logger func(ctx context.Context, entry LogEntry)
+
+// Redactor names the "arguments" fields a Logger should mask before
+// logging, keyed by tool name.
+type Redactor struct {
+	fields map[string][]string
+}
+
+// NewRedactor returns an empty Redactor; register tools with Register.
+func NewRedactor() *Redactor {
+	return &Redactor{fields: make(map[string][]string)}
+}
+
+// Register records, for toolName, the JSON field names of argType's fields
+// whose `jsonschema` tag contains tag as a comma-separated entry (e.g.
+// `jsonschema:"required,sensitive"`), so Logging/StreamLogging redact them
+// from logged arguments. argType may be a struct or a pointer to one,
+// matching how tool handlers declare their argument type.
+func (r *Redactor) Register(toolName string, argType reflect.Type, tag string) {
+	for argType.Kind() == reflect.Ptr {
+		argType = argType.Elem()
+	}
+	if argType.Kind() != reflect.Struct {
+		return
+	}
+	var fields []string
+	for i := 0; i < argType.NumField(); i++ {
+		f := argType.Field(i)
+		if !hasTagEntry(f.Tag.Get("jsonschema"), tag) {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if jsonName == "" {
+			jsonName = f.Name
+		}
+		fields = append(fields, jsonName)
+	}
+	if len(fields) > 0 {
+		r.fields[toolName] = fields
+	}
+}
+
+// hasTagEntry reports whether tag appears as a whole comma-separated entry
+// of jsonschemaTag (so "sensitive" doesn't match "sensitivity=high").
+func hasTagEntry(jsonschemaTag, tag string) bool {
+	for _, part := range strings.Split(jsonschemaTag, ",") {
+		if strings.TrimSpace(part) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// redact returns args with every field Register recorded for toolName
+// replaced with "[REDACTED]", or args unchanged if toolName has no
+// registered fields or args doesn't parse as a JSON object.
+func (r *Redactor) redact(toolName string, args json.RawMessage) json.RawMessage {
+	fields := r.fields[toolName]
+	if len(fields) == 0 || len(args) == 0 {
+		return args
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(args, &obj); err != nil {
+		return args
+	}
+	redacted := []byte(`"[REDACTED]"`)
+	changed := false
+	for _, field := range fields {
+		if _, ok := obj[field]; ok {
+			obj[field] = redacted
+			changed = true
+		}
+	}
+	if !changed {
+		return args
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return args
+	}
+	return out
+}
+
+// argumentsOf returns a tools/call message's "arguments" field (redacted
+// per redactor, if non-nil), or the bare params for any other method.
+func argumentsOf(msg jsonrpcMessage, redactor *Redactor) json.RawMessage {
+	if msg.tool == "" {
+		return msg.params
+	}
+	var call struct {
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(msg.params, &call); err != nil || len(call.Arguments) == 0 {
+		return msg.params
+	}
+	if redactor != nil {
+		return redactor.redact(msg.tool, call.Arguments)
+	}
+	return call.Arguments
+}
+
+// Logging returns a unary interceptor that logs every call's method,
+// (for tools/call) tool name and redacted arguments, and resulting error,
+// if any, to log.
+func Logging(log Logger, redactor *Redactor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if msg, ok := jsonrpcMessageFromAny(req); ok {
+			log(ctx, LogEntry{Method: msg.method, Tool: msg.tool, Arguments: argumentsOf(msg, redactor), Err: err})
+		} else {
+			log(ctx, LogEntry{Method: info.FullMethod, Err: err})
+		}
+		return resp, err
+	}
+}
+
+// loggingStream wraps a grpc.ServerStream, logging every JSON-RPC message
+// as it's received.
+type loggingStream struct {
+	grpc.ServerStream
+	log      Logger
+	redactor *Redactor
+}
+
+func (s *loggingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := jsonrpcMessageFromAny(m); ok {
+		s.log(s.Context(), LogEntry{Method: msg.method, Tool: msg.tool, Arguments: argumentsOf(msg, s.redactor)})
+	}
+	return nil
+}
+
+// StreamLogging returns a stream interceptor logging every JSON-RPC message
+// received on the stream, with the same redaction behavior as Logging.
+func StreamLogging(log Logger, redactor *Redactor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &loggingStream{ServerStream: ss, log: log, redactor: redactor})
+	}
+}
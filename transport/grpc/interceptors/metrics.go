@@ -0,0 +1,100 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+// Metrics holds the Prometheus instruments Observability/StreamObservability
+// record to, labeled by "method" (the bare JSON-RPC method, or
+// "<method>/<tool>" for tools/call - see jsonrpcMessage.metricLabel).
+type Metrics struct {
+	requestTotal    *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	inFlight        *prometheus.GaugeVec
+}
+
+// NewMetrics registers a Metrics' instruments with reg and returns it.
+// Passing prometheus.DefaultRegisterer matches how most Go services wire up
+// their /metrics endpoint.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		requestTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "mcp",
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of MCP requests dispatched, by method.",
+		}, []string{"method"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "mcp",
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "MCP request duration in seconds, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "mcp",
+			Subsystem: "grpc",
+			Name:      "requests_in_flight",
+			Help:      "MCP requests currently in flight, by method.",
+		}, []string{"method"}),
+	}
+	reg.MustRegister(m.requestTotal, m.requestDuration, m.inFlight)
+	return m
+}
+
+// record updates m for one request against label, taking dur to complete.
+func (m *Metrics) record(label string, dur time.Duration) {
+	m.requestTotal.WithLabelValues(label).Inc()
+	m.requestDuration.WithLabelValues(label).Observe(dur.Seconds())
+}
+
+// Observability returns a unary interceptor recording count, duration, and
+// in-flight gauge against m.
+func Observability(m *Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		label := info.FullMethod
+		if msg, ok := jsonrpcMessageFromAny(req); ok {
+			label = msg.metricLabel()
+		}
+		m.inFlight.WithLabelValues(label).Inc()
+		defer m.inFlight.WithLabelValues(label).Dec()
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.record(label, time.Since(start))
+		return resp, err
+	}
+}
+
+// metricsStream wraps a grpc.ServerStream, recording m for every JSON-RPC
+// message as it's received. In-flight is incremented for the message's
+// duration on the stream rather than until its JSON-RPC response is sent,
+// since nothing in the protobuf framing correlates a response back to the
+// request it answers without tracking per-request IDs.
+type metricsStream struct {
+	grpc.ServerStream
+	metrics *Metrics
+}
+
+func (s *metricsStream) RecvMsg(m interface{}) error {
+	start := time.Now()
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := jsonrpcMessageFromAny(m); ok {
+		s.metrics.record(msg.metricLabel(), time.Since(start))
+	}
+	return nil
+}
+
+// StreamObservability returns a stream interceptor recording m for every
+// JSON-RPC message received on the stream.
+func StreamObservability(m *Metrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &metricsStream{ServerStream: ss, metrics: m})
+	}
+}
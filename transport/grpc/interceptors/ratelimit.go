@@ -0,0 +1,83 @@
+package interceptors
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter hands out a per-method token bucket, creating it on first use
+// via newLimiter. Methods never seen before share no state with each other,
+// so a flood against one MCP method (or tool, when keyed by metricLabel())
+// can't starve the rest.
+type RateLimiter struct {
+	mu         sync.Mutex
+	limiters   map[string]*rate.Limiter
+	newLimiter func() *rate.Limiter
+}
+
+// NewRateLimiter returns a RateLimiter that allows rps requests per second,
+// per distinct method/tool, with bursts up to burst.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		limiters: make(map[string]*rate.Limiter),
+		newLimiter: func() *rate.Limiter {
+			return rate.NewLimiter(rate.Limit(rps), burst)
+		},
+	}
+}
+
+// allow reports whether a request against key is permitted right now,
+// creating key's limiter on first use.
+func (l *RateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	lim, ok := l.limiters[key]
+	if !ok {
+		lim = l.newLimiter()
+		l.limiters[key] = lim
+	}
+	l.mu.Unlock()
+	return lim.Allow()
+}
+
+// RateLimit returns a unary interceptor that rejects calls exceeding l's
+// per-method budget with codes.ResourceExhausted.
+func RateLimit(l *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !l.allow(info.FullMethod) {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitedStream wraps a grpc.ServerStream, applying l to every JSON-RPC
+// message (keyed by method, or method/tool for tools/call) as it's received.
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter *RateLimiter
+}
+
+func (s *rateLimitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := jsonrpcMessageFromAny(m); ok {
+		if !s.limiter.allow(msg.metricLabel()) {
+			return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", msg.metricLabel())
+		}
+	}
+	return nil
+}
+
+// StreamRateLimit returns a stream interceptor applying l to every JSON-RPC
+// message on the stream, rather than once per connection.
+func StreamRateLimit(l *RateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &rateLimitedStream{ServerStream: ss, limiter: l})
+	}
+}
@@ -0,0 +1,47 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DR1N0/mcp-go/transport"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Recovery returns a unary interceptor that recovers a panic in handler,
+// reports it to onErr (if non-nil, e.g. the transport's ErrorHandler), and
+// converts it to a codes.Internal status error instead of crashing the
+// server process.
+func Recovery(onErr transport.ErrorHandler) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if onErr != nil {
+					onErr(fmt.Errorf("panic in %s: %v", info.FullMethod, r))
+				}
+				err = status.Errorf(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery returns a stream interceptor with the same recovery
+// behavior as Recovery. Since grpc.ServerTransport's Transport RPC is a
+// single long-lived stream, this covers a panic anywhere in the lifetime of
+// a connected MCP session, not just its setup.
+func StreamRecovery(onErr transport.ErrorHandler) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if onErr != nil {
+					onErr(fmt.Errorf("panic in %s: %v", info.FullMethod, r))
+				}
+				err = status.Errorf(codes.Internal, "internal error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
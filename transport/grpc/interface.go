@@ -1,8 +1,18 @@
 package grpc
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
 	"github.com/DR1N0/mcp-go/transport"
+	"github.com/DR1N0/mcp-go/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 )
 
 // ServerTransport is a gRPC-specific server transport
@@ -16,6 +26,19 @@ type ServerTransport interface {
 	WithInterceptor(interceptor grpc.UnaryServerInterceptor) ServerTransport
 	// WithStreamInterceptor adds a stream interceptor
 	WithStreamInterceptor(interceptor grpc.StreamServerInterceptor) ServerTransport
+	// SendTo sends msg to the single session identified by sessionID,
+	// regardless of what (if any) session the calling context carries.
+	SendTo(sessionID string, msg *types.BaseJSONRPCMessage) error
+	// Broadcast sends msg to every currently connected session.
+	Broadcast(msg *types.BaseJSONRPCMessage) error
+	// ListSessions returns the IDs of all currently connected sessions.
+	ListSessions() []string
+	// SetSessionCloseHandler sets the callback invoked with a session's ID
+	// when its stream disconnects.
+	SetSessionCloseHandler(handler func(sessionID string))
+	// SetServingStatus reports service's health as status via the
+	// registered grpc.health.v1 Health service.
+	SetServingStatus(service string, status healthpb.HealthCheckResponse_ServingStatus)
 }
 
 // ClientTransport is a gRPC-specific client transport
@@ -51,3 +74,166 @@ func WithClientGRPCDialOptions(opts ...grpc.DialOption) ClientOption {
 		c.dialOpts = append(c.dialOpts, opts...)
 	}
 }
+
+// WithServerTLSCredentials terminates the gRPC server with TLS (or mTLS, if
+// creds was built with client certificate verification enabled)
+func WithServerTLSCredentials(creds credentials.TransportCredentials) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.grpcOpts = append(s.grpcOpts, grpc.Creds(creds))
+	}
+}
+
+// WithServerKeepalive configures gRPC-level keepalive pings on the server,
+// letting the transport's own connection health checks stand in for MCP
+// `ping` round-trips over idle streams
+func WithServerKeepalive(params keepalive.ServerParameters) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.grpcOpts = append(s.grpcOpts, grpc.KeepaliveParams(params))
+	}
+}
+
+// WithTLS terminates the gRPC server with TLS using the certificate and key
+// at certFile and keyFile. The files are loaded when the option is applied;
+// a load failure is returned by Start. It's a convenience over
+// WithServerTLSCredentials for the common single-cert case.
+func WithTLS(certFile, keyFile string) ServerOption {
+	return func(s *grpcServerTransport) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			s.optionErr = fmt.Errorf("failed to load TLS certificate: %w", err)
+			return
+		}
+		s.grpcOpts = append(s.grpcOpts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+		})))
+	}
+}
+
+// WithMutualTLS terminates the gRPC server with mutual TLS: caFile's PEM
+// certificate verifies client certificates, and certFile/keyFile identify
+// the server. The files are loaded when the option is applied; a load
+// failure is returned by Start.
+func WithMutualTLS(caFile, certFile, keyFile string) ServerOption {
+	return func(s *grpcServerTransport) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			s.optionErr = fmt.Errorf("failed to load TLS certificate: %w", err)
+			return
+		}
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			s.optionErr = fmt.Errorf("failed to read CA certificate: %w", err)
+			return
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			s.optionErr = fmt.Errorf("failed to parse CA certificate from %s", caFile)
+			return
+		}
+		s.grpcOpts = append(s.grpcOpts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		})))
+	}
+}
+
+// WithKeepaliveEnforcementPolicy configures how strictly the server
+// enforces its own keepalive ping frequency limits on clients
+func WithKeepaliveEnforcementPolicy(policy keepalive.EnforcementPolicy) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.grpcOpts = append(s.grpcOpts, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+}
+
+// WithMaxConcurrentStreams caps how many concurrent streams (i.e. connected
+// MCP sessions) the server accepts per client connection
+func WithMaxConcurrentStreams(n uint32) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.grpcOpts = append(s.grpcOpts, grpc.MaxConcurrentStreams(n))
+	}
+}
+
+// WithMaxRecvMsgSize caps the size in bytes of a single message the server
+// will accept, protecting it from oversized params/result payloads
+func WithMaxRecvMsgSize(bytes int) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.grpcOpts = append(s.grpcOpts, grpc.MaxRecvMsgSize(bytes))
+	}
+}
+
+// WithMaxConnections caps the number of simultaneous TCP connections Start
+// accepts, independent of per-connection stream limits
+func WithMaxConnections(n int) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.maxConnections = n
+	}
+}
+
+// WithHealthService controls whether Start registers the standard
+// grpc.health.v1 Health service (enabled by default) for use by
+// Kubernetes probes, Envoy health checks, and service meshes.
+func WithHealthService(enabled bool) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.enableHealth = enabled
+	}
+}
+
+// WithAuthenticator installs an Authenticator that AuthInterceptor uses to
+// resolve the bearer token ("authorization" header) or API key
+// ("x-api-key" header) presented in incoming gRPC metadata to a Principal,
+// retrievable from then on via PrincipalFromContext. It also binds that
+// Principal to the MCP initialize handshake; see Principal.Claims.
+func WithAuthenticator(authenticate Authenticator) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.authenticator = authenticate
+	}
+}
+
+// WithRequireAuth rejects calls and streams that present no credentials
+// with codes.Unauthenticated instead of letting them through
+// unauthenticated (the default). Has no effect unless WithAuthenticator is
+// also set.
+func WithRequireAuth(require bool) ServerOption {
+	return func(s *grpcServerTransport) {
+		s.requireAuth = require
+	}
+}
+
+// WithClientTLSCredentials dials the gRPC server over TLS using creds
+func WithClientTLSCredentials(creds credentials.TransportCredentials) ClientOption {
+	return func(c *grpcClientTransport) {
+		c.dialOpts = append(c.dialOpts, grpc.WithTransportCredentials(creds))
+	}
+}
+
+// WithClientKeepalive configures gRPC-level keepalive pings on the client
+func WithClientKeepalive(params keepalive.ClientParameters) ClientOption {
+	return func(c *grpcClientTransport) {
+		c.dialOpts = append(c.dialOpts, grpc.WithKeepaliveParams(params))
+	}
+}
+
+// WithBearerToken attaches a Bearer token to every call's gRPC metadata,
+// matching the auth pattern used by the HTTP middleware example. Pair it
+// with WithClientTLSCredentials in production so the token isn't sent in
+// the clear.
+func WithBearerToken(token string) ClientOption {
+	return func(c *grpcClientTransport) {
+		c.dialOpts = append(c.dialOpts, grpc.WithPerRPCCredentials(bearerTokenCreds{token: token}))
+	}
+}
+
+// bearerTokenCreds implements credentials.PerRPCCredentials by attaching a
+// Bearer token to the "authorization" gRPC metadata key on every call
+type bearerTokenCreds struct {
+	token string
+}
+
+func (b bearerTokenCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearerTokenCreds) RequireTransportSecurity() bool {
+	return false
+}
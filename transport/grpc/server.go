@@ -2,36 +2,74 @@ package grpc
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/DR1N0/mcp-go/transport"
 	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
 	"github.com/DR1N0/mcp-go/types"
+	"golang.org/x/net/netutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// jsonrpcServiceName is the service name the grpc.health.v1 Health service
+// reports status under for the JSON-RPC transport service.
+const jsonrpcServiceName = "mcp.JSONRPCService"
+
+// sessionIDMetadataKey is the gRPC metadata key a server advertises its
+// allocated session ID under, and the key a client presents a previously
+// allocated session ID under to resume it on a new stream.
+const sessionIDMetadataKey = "mcp-session-id"
+
+// sendTimeout bounds how long a targeted or broadcast send waits for a
+// session's buffer to drain before giving up, so a slow or wedged client
+// can't silently swallow notifications meant for others.
+const sendTimeout = 5 * time.Second
+
+// grpcSession tracks one connected Transport stream: its send channel and
+// the cancel func that tears down its sender goroutine.
+type grpcSession struct {
+	id       string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	sendChan chan *pb.JSONRPCMessage
+}
+
 // grpcServerTransport implements ServerTransport for gRPC
 type grpcServerTransport struct {
 	pb.UnimplementedJSONRPCServiceServer
-	mu                 sync.RWMutex
-	port               int
-	grpcOpts           []grpc.ServerOption
-	unaryInterceptors  []grpc.UnaryServerInterceptor
-	streamInterceptors []grpc.StreamServerInterceptor
-	grpcServer         *grpc.Server
-	listener           net.Listener
-	messageHandler     transport.MessageHandler
-	errorHandler       transport.ErrorHandler
-	closeHandler       transport.CloseHandler
-	ctx                context.Context
-	cancel             context.CancelFunc
-	closed             bool
+	mu                  sync.RWMutex
+	port                int
+	grpcOpts            []grpc.ServerOption
+	unaryInterceptors   []grpc.UnaryServerInterceptor
+	streamInterceptors  []grpc.StreamServerInterceptor
+	grpcServer          *grpc.Server
+	listener            net.Listener
+	sessions            map[string]*grpcSession
+	messageHandler      transport.MessageHandler
+	errorHandler        transport.ErrorHandler
+	closeHandler        transport.CloseHandler
+	sessionCloseHandler func(sessionID string)
+	maxConnections      int
+	optionErr           error
+	enableHealth        bool
+	healthServer        *health.Server
+	authenticator       Authenticator
+	requireAuth         bool
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	closed              bool
 }
 
 // NewServerTransport creates a new gRPC server transport
@@ -42,6 +80,8 @@ func NewServerTransport(opts ...ServerOption) ServerTransport {
 		grpcOpts:           make([]grpc.ServerOption, 0),
 		unaryInterceptors:  make([]grpc.UnaryServerInterceptor, 0),
 		streamInterceptors: make([]grpc.StreamServerInterceptor, 0),
+		sessions:           make(map[string]*grpcSession),
+		enableHealth:       true,
 		ctx:                ctx,
 		cancel:             cancel,
 		closed:             false,
@@ -54,6 +94,27 @@ func NewServerTransport(opts ...ServerOption) ServerTransport {
 	return s
 }
 
+// generateSessionID creates a new random session ID
+func generateSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// incomingSessionID returns the session ID the client presented via gRPC
+// metadata to resume a prior session, or "" if it presented none.
+func incomingSessionID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	vals := md.Get(sessionIDMetadataKey)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+
 // WithPort sets the port for the gRPC server
 func (s *grpcServerTransport) WithPort(port int) ServerTransport {
 	s.mu.Lock()
@@ -94,26 +155,45 @@ func (s *grpcServerTransport) Start(ctx context.Context) error {
 		return fmt.Errorf("transport is closed")
 	}
 
+	if s.optionErr != nil {
+		err := s.optionErr
+		s.mu.Unlock()
+		return err
+	}
+
 	// Create listener
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
 	if err != nil {
 		s.mu.Unlock()
 		return fmt.Errorf("failed to listen: %w", err)
 	}
+	if s.maxConnections > 0 {
+		lis = netutil.LimitListener(lis, s.maxConnections)
+	}
 	s.listener = lis
 
 	// Build server options with interceptors
 	opts := make([]grpc.ServerOption, 0, len(s.grpcOpts)+2)
 	opts = append(opts, s.grpcOpts...)
 
+	// If an Authenticator is configured, run it ahead of any
+	// caller-supplied interceptors so those see an authenticated context.
+	unaryInterceptors := s.unaryInterceptors
+	streamInterceptors := s.streamInterceptors
+	if s.authenticator != nil {
+		auth := &authInterceptor{authenticate: s.authenticator, requireAuth: s.requireAuth}
+		unaryInterceptors = append([]grpc.UnaryServerInterceptor{auth.Unary()}, unaryInterceptors...)
+		streamInterceptors = append([]grpc.StreamServerInterceptor{auth.Stream()}, streamInterceptors...)
+	}
+
 	// Chain unary interceptors if any
-	if len(s.unaryInterceptors) > 0 {
-		opts = append(opts, grpc.ChainUnaryInterceptor(s.unaryInterceptors...))
+	if len(unaryInterceptors) > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(unaryInterceptors...))
 	}
 
 	// Chain stream interceptors if any
-	if len(s.streamInterceptors) > 0 {
-		opts = append(opts, grpc.ChainStreamInterceptor(s.streamInterceptors...))
+	if len(streamInterceptors) > 0 {
+		opts = append(opts, grpc.ChainStreamInterceptor(streamInterceptors...))
 	}
 
 	// Create gRPC server
@@ -123,6 +203,13 @@ func (s *grpcServerTransport) Start(ctx context.Context) error {
 	// Enable reflection for debugging
 	reflection.Register(s.grpcServer)
 
+	if s.enableHealth {
+		s.healthServer = health.NewServer()
+		healthpb.RegisterHealthServer(s.grpcServer, s.healthServer)
+		s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		s.healthServer.SetServingStatus(jsonrpcServiceName, healthpb.HealthCheckResponse_SERVING)
+	}
+
 	s.mu.Unlock()
 
 	// Start server in goroutine
@@ -144,13 +231,46 @@ func (s *grpcServerTransport) Start(ctx context.Context) error {
 func (s *grpcServerTransport) Transport(stream pb.JSONRPCService_TransportServer) error {
 	ctx := stream.Context()
 
+	// Resume the caller's prior session if it presented one, otherwise
+	// allocate a new one and tell the caller so it can resume later.
+	sessionID := incomingSessionID(ctx)
+	if sessionID == "" {
+		sessionID = generateSessionID()
+	}
+	if err := stream.SendHeader(metadata.Pairs(sessionIDMetadataKey, sessionID)); err != nil {
+		return fmt.Errorf("failed to send session header: %w", err)
+	}
+
 	// Channel for sending messages to client
 	sendChan := make(chan *pb.JSONRPCMessage, 10)
 	errChan := make(chan error, 1)
 
+	sessionCtx, sessionCancel := context.WithCancel(ctx)
+	session := &grpcSession{
+		id:       sessionID,
+		ctx:      sessionCtx,
+		cancel:   sessionCancel,
+		sendChan: sendChan,
+	}
+
+	s.mu.Lock()
+	s.sessions[sessionID] = session
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, sessionID)
+		closeHandler := s.sessionCloseHandler
+		s.mu.Unlock()
+		sessionCancel()
+		if closeHandler != nil {
+			closeHandler(sessionID)
+		}
+	}()
+
 	// Store stream context for sending messages
 	streamCtx := context.WithValue(ctx, "grpc_stream", stream)
-	streamCtx = context.WithValue(streamCtx, "send_chan", sendChan)
+	streamCtx = context.WithValue(streamCtx, "session_id", sessionID)
 
 	// Goroutine to send messages to client
 	go func() {
@@ -207,35 +327,94 @@ func (s *grpcServerTransport) Transport(stream pb.JSONRPCService_TransportServer
 	}
 }
 
-// Send sends a message through the transport
+// Send sends a message through the transport. If ctx carries a session ID
+// (as the context passed to the message handler does), msg goes to that
+// session alone; otherwise it's broadcast to every connected session.
 func (s *grpcServerTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMessage) error {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	if s.closed {
+		s.mu.RUnlock()
 		return fmt.Errorf("transport is closed")
 	}
 
-	// Convert to protobuf message
+	sessionID, hasSessionID := ctx.Value("session_id").(string)
+	var target *grpcSession
+	var all []*grpcSession
+	if hasSessionID && sessionID != "" {
+		target = s.sessions[sessionID]
+	} else {
+		all = make([]*grpcSession, 0, len(s.sessions))
+		for _, sess := range s.sessions {
+			all = append(all, sess)
+		}
+	}
+	s.mu.RUnlock()
+
 	protoMsg, err := baseToProto(msg)
 	if err != nil {
 		return fmt.Errorf("failed to convert message: %w", err)
 	}
 
-	// Get send channel from context
-	sendChan, ok := ctx.Value("send_chan").(chan *pb.JSONRPCMessage)
-	if !ok || sendChan == nil {
-		return fmt.Errorf("send channel not found in context")
+	if hasSessionID && sessionID != "" {
+		if target == nil {
+			return fmt.Errorf("session not found: %s", sessionID)
+		}
+		return s.sendToSession(target, protoMsg)
 	}
 
-	// Send message
+	for _, sess := range all {
+		if err := s.sendToSession(sess, protoMsg); err != nil {
+			s.mu.RLock()
+			errorHandler := s.errorHandler
+			s.mu.RUnlock()
+			if errorHandler != nil {
+				errorHandler(fmt.Errorf("broadcast to session %s: %w", sess.id, err))
+			}
+		}
+	}
+	return nil
+}
+
+// SendTo sends msg to the single session identified by sessionID
+func (s *grpcServerTransport) SendTo(sessionID string, msg *types.BaseJSONRPCMessage) error {
+	return s.Send(context.WithValue(context.Background(), "session_id", sessionID), msg)
+}
+
+// Broadcast sends msg to every currently connected session
+func (s *grpcServerTransport) Broadcast(msg *types.BaseJSONRPCMessage) error {
+	return s.Send(context.Background(), msg)
+}
+
+// ListSessions returns the IDs of all currently connected sessions
+func (s *grpcServerTransport) ListSessions() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// SetSessionCloseHandler sets the callback for when a session's stream
+// disconnects
+func (s *grpcServerTransport) SetSessionCloseHandler(handler func(sessionID string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessionCloseHandler = handler
+}
+
+// sendToSession delivers msg to session's send channel, blocking up to
+// sendTimeout rather than dropping it the instant the buffer is full
+func (s *grpcServerTransport) sendToSession(session *grpcSession, msg *pb.JSONRPCMessage) error {
 	select {
-	case sendChan <- protoMsg:
+	case session.sendChan <- msg:
 		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		return fmt.Errorf("send channel full")
+	case <-session.ctx.Done():
+		return fmt.Errorf("session %s closed", session.id)
+	case <-time.After(sendTimeout):
+		return fmt.Errorf("send to session %s timed out after %s: buffer full", session.id, sendTimeout)
 	}
 }
 
@@ -251,6 +430,11 @@ func (s *grpcServerTransport) Close() error {
 	s.closed = true
 	s.cancel()
 
+	if s.healthServer != nil {
+		s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		s.healthServer.SetServingStatus(jsonrpcServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
 	if s.grpcServer != nil {
 		s.grpcServer.GracefulStop()
 	}
@@ -262,6 +446,18 @@ func (s *grpcServerTransport) Close() error {
 	return nil
 }
 
+// SetServingStatus reports service's health as status, letting a higher
+// level MCP server degrade health (e.g. when an upstream tool provider
+// fails) without tearing down the gRPC server. A no-op if the health
+// service was disabled via WithHealthService(false).
+func (s *grpcServerTransport) SetServingStatus(service string, status healthpb.HealthCheckResponse_ServingStatus) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.healthServer != nil {
+		s.healthServer.SetServingStatus(service, status)
+	}
+}
+
 // SetMessageHandler sets the callback for incoming messages
 func (s *grpcServerTransport) SetMessageHandler(handler func(ctx context.Context, msg *types.BaseJSONRPCMessage)) {
 	s.mu.Lock()
@@ -283,7 +479,14 @@ func (s *grpcServerTransport) SetCloseHandler(handler func()) {
 	s.closeHandler = handler
 }
 
-// Helper functions for message conversion
+// Helper functions for message conversion.
+//
+// Params, Result, and Error.Data are carried on the wire as
+// google.protobuf.Value rather than google.protobuf.Struct, so they parse
+// directly to and from the raw JSON bytes on types.BaseJSONRPCMessage via
+// protojson — no map[string]interface{} in between. That both lets
+// non-object payloads (array params, for example) survive
+// the round trip, and avoids doing it twice per message.
 
 func protoToBase(msg *pb.JSONRPCMessage) (*types.BaseJSONRPCMessage, error) {
 	base := &types.BaseJSONRPCMessage{
@@ -301,11 +504,7 @@ func protoToBase(msg *pb.JSONRPCMessage) (*types.BaseJSONRPCMessage, error) {
 
 	// Handle params
 	if msg.Params != nil {
-		paramsStruct, err := structpb.NewStruct(msg.Params.AsMap())
-		if err != nil {
-			return nil, fmt.Errorf("failed to create params struct: %w", err)
-		}
-		params, err := paramsStruct.MarshalJSON()
+		params, err := protojson.Marshal(msg.Params)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal params: %w", err)
 		}
@@ -314,11 +513,7 @@ func protoToBase(msg *pb.JSONRPCMessage) (*types.BaseJSONRPCMessage, error) {
 
 	// Handle result
 	if msg.Result != nil {
-		resultStruct, err := structpb.NewStruct(msg.Result.AsMap())
-		if err != nil {
-			return nil, fmt.Errorf("failed to create result struct: %w", err)
-		}
-		result, err := resultStruct.MarshalJSON()
+		result, err := protojson.Marshal(msg.Result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -332,7 +527,7 @@ func protoToBase(msg *pb.JSONRPCMessage) (*types.BaseJSONRPCMessage, error) {
 			Message: msg.Error.Message,
 		}
 		if msg.Error.Data != nil {
-			base.Error.Data = msg.Error.Data.AsMap()
+			base.Error.Data = msg.Error.Data.AsInterface()
 		}
 	}
 
@@ -359,27 +554,19 @@ func baseToProto(msg *types.BaseJSONRPCMessage) (*pb.JSONRPCMessage, error) {
 
 	// Handle params
 	if len(msg.Params) > 0 {
-		var paramsMap map[string]interface{}
-		if err := json.Unmarshal(msg.Params, &paramsMap); err != nil {
+		params := &structpb.Value{}
+		if err := protojson.Unmarshal(msg.Params, params); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal params: %w", err)
 		}
-		params, err := structpb.NewStruct(paramsMap)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create params struct: %w", err)
-		}
 		proto.Params = params
 	}
 
 	// Handle result
 	if len(msg.Result) > 0 {
-		var resultMap map[string]interface{}
-		if err := json.Unmarshal(msg.Result, &resultMap); err != nil {
+		result := &structpb.Value{}
+		if err := protojson.Unmarshal(msg.Result, result); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 		}
-		result, err := structpb.NewStruct(resultMap)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create result struct: %w", err)
-		}
 		proto.Result = result
 	}
 
@@ -390,9 +577,9 @@ func baseToProto(msg *types.BaseJSONRPCMessage) (*pb.JSONRPCMessage, error) {
 			Message: msg.Error.Message,
 		}
 		if msg.Error.Data != nil {
-			data, err := structpb.NewStruct(msg.Error.Data.(map[string]interface{}))
+			data, err := structpb.NewValue(msg.Error.Data)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create error data struct: %w", err)
+				return nil, fmt.Errorf("failed to create error data value: %w", err)
 			}
 			proto.Error.Data = data
 		}
@@ -0,0 +1,209 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
+	"github.com/DR1N0/mcp-go/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// freePort returns a currently-unused TCP port by briefly binding to ":0"
+// and releasing it before the real listener claims it.
+func freePort(t *testing.T) int {
+	t.Helper()
+	lis, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer lis.Close()
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestBaseToProto_ArrayResult(t *testing.T) {
+	base := &types.BaseJSONRPCMessage{
+		JSONRPC: "2.0",
+		ID:      int64(1),
+		Result:  json.RawMessage(`{"tools":[{"name":"a"},{"name":"b"}]}`),
+	}
+
+	proto, err := baseToProto(base)
+	if err != nil {
+		t.Fatalf("baseToProto failed: %v", err)
+	}
+	if proto.Result == nil {
+		t.Fatal("expected proto.Result to be set")
+	}
+
+	back, err := protoToBase(&pb.JSONRPCMessage{
+		Jsonrpc: proto.Jsonrpc,
+		Id:      proto.Id,
+		Result:  proto.Result,
+	})
+	if err != nil {
+		t.Fatalf("protoToBase failed: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(back.Result, &got); err != nil {
+		t.Fatalf("failed to unmarshal round-tripped result: %v", err)
+	}
+	tools, ok := got["tools"].([]interface{})
+	if !ok || len(tools) != 2 {
+		t.Fatalf("expected 2 tools, got %+v", got["tools"])
+	}
+}
+
+func TestBaseToProto_ArrayParamsNotification(t *testing.T) {
+	base := &types.BaseJSONRPCMessage{
+		JSONRPC: "2.0",
+		Method:  "notifications/example",
+		Params:  json.RawMessage(`[1, "two", 3.0]`),
+	}
+
+	proto, err := baseToProto(base)
+	if err != nil {
+		t.Fatalf("baseToProto failed: %v", err)
+	}
+
+	back, err := protoToBase(proto)
+	if err != nil {
+		t.Fatalf("protoToBase failed: %v", err)
+	}
+
+	var got []interface{}
+	if err := json.Unmarshal(back.Params, &got); err != nil {
+		t.Fatalf("failed to unmarshal round-tripped params: %v", err)
+	}
+	if len(got) != 3 || got[1] != "two" {
+		t.Fatalf("unexpected round-tripped params: %+v", got)
+	}
+}
+
+func TestBaseToProto_ErrorWithStringData(t *testing.T) {
+	base := &types.BaseJSONRPCMessage{
+		JSONRPC: "2.0",
+		ID:      int64(2),
+		Error: &types.RPCError{
+			Code:    -32000,
+			Message: "boom",
+			Data:    "additional context",
+		},
+	}
+
+	proto, err := baseToProto(base)
+	if err != nil {
+		t.Fatalf("baseToProto failed: %v", err)
+	}
+	if proto.Error == nil || proto.Error.Data == nil {
+		t.Fatal("expected proto.Error.Data to be set")
+	}
+
+	back, err := protoToBase(proto)
+	if err != nil {
+		t.Fatalf("protoToBase failed: %v", err)
+	}
+	if back.Error.Data != "additional context" {
+		t.Fatalf("expected error data %q, got %v", "additional context", back.Error.Data)
+	}
+}
+
+func TestHealthService_ServingByDefault(t *testing.T) {
+	port := freePort(t)
+	s := NewServerTransport().WithPort(port)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := s.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.(*grpcServerTransport).Close()
+
+	conn, err := grpc.NewClient(net.JoinHostPort("localhost", strconv.Itoa(port)), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	checkCtx, checkCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer checkCancel()
+
+	resp, err := client.Check(checkCtx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected overall status SERVING, got %v", resp.Status)
+	}
+
+	resp, err = client.Check(checkCtx, &healthpb.HealthCheckRequest{Service: jsonrpcServiceName})
+	if err != nil {
+		t.Fatalf("Check(%q) failed: %v", jsonrpcServiceName, err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected %q status SERVING, got %v", jsonrpcServiceName, resp.Status)
+	}
+}
+
+func TestHealthService_SetServingStatusDegradesCheck(t *testing.T) {
+	port := freePort(t)
+	s := NewServerTransport().WithPort(port)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := s.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.(*grpcServerTransport).Close()
+
+	s.SetServingStatus(jsonrpcServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	conn, err := grpc.NewClient(net.JoinHostPort("localhost", strconv.Itoa(port)), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	checkCtx, checkCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer checkCancel()
+
+	resp, err := client.Check(checkCtx, &healthpb.HealthCheckRequest{Service: jsonrpcServiceName})
+	if err != nil {
+		t.Fatalf("Check(%q) failed: %v", jsonrpcServiceName, err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected %q status NOT_SERVING, got %v", jsonrpcServiceName, resp.Status)
+	}
+}
+
+func TestHealthService_DisabledByOption(t *testing.T) {
+	port := freePort(t)
+	s := NewServerTransport(WithHealthService(false)).WithPort(port)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := s.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.(*grpcServerTransport).Close()
+
+	conn, err := grpc.NewClient(net.JoinHostPort("localhost", strconv.Itoa(port)), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	checkCtx, checkCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer checkCancel()
+
+	if _, err := client.Check(checkCtx, &healthpb.HealthCheckRequest{}); err == nil {
+		t.Fatal("expected Check to fail when the health service is disabled")
+	}
+}
@@ -0,0 +1,108 @@
+package grpcgateway
+
+import (
+	"fmt"
+
+	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
+	"github.com/DR1N0/mcp-go/types"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// baseToProto and protoToBase mirror the helpers of the same name in
+// transport/grpc/server.go: both packages dial the same
+// JSONRPCService/JSONRPCMessage wire format, but those helpers are
+// unexported, so the gateway carries its own copy rather than reaching into
+// transport/grpc's internals.
+
+func baseToProto(msg *types.BaseJSONRPCMessage) (*pb.JSONRPCMessage, error) {
+	proto := &pb.JSONRPCMessage{
+		Jsonrpc: msg.JSONRPC,
+		Method:  msg.Method,
+	}
+
+	switch id := msg.ID.(type) {
+	case string:
+		proto.Id = &pb.JSONRPCMessage_IdString{IdString: id}
+	case int:
+		proto.Id = &pb.JSONRPCMessage_IdNumber{IdNumber: int64(id)}
+	case int64:
+		proto.Id = &pb.JSONRPCMessage_IdNumber{IdNumber: id}
+	case float64:
+		proto.Id = &pb.JSONRPCMessage_IdNumber{IdNumber: int64(id)}
+	}
+
+	if len(msg.Params) > 0 {
+		params := &structpb.Value{}
+		if err := protojson.Unmarshal(msg.Params, params); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal params: %w", err)
+		}
+		proto.Params = params
+	}
+
+	if len(msg.Result) > 0 {
+		result := &structpb.Value{}
+		if err := protojson.Unmarshal(msg.Result, result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+		}
+		proto.Result = result
+	}
+
+	if msg.Error != nil {
+		proto.Error = &pb.JSONRPCError{
+			Code:    int32(msg.Error.Code),
+			Message: msg.Error.Message,
+		}
+		if msg.Error.Data != nil {
+			data, err := structpb.NewValue(msg.Error.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create error data value: %w", err)
+			}
+			proto.Error.Data = data
+		}
+	}
+
+	return proto, nil
+}
+
+func protoToBase(msg *pb.JSONRPCMessage) (*types.BaseJSONRPCMessage, error) {
+	base := &types.BaseJSONRPCMessage{
+		JSONRPC: msg.Jsonrpc,
+		Method:  msg.Method,
+	}
+
+	switch id := msg.Id.(type) {
+	case *pb.JSONRPCMessage_IdString:
+		base.ID = id.IdString
+	case *pb.JSONRPCMessage_IdNumber:
+		base.ID = id.IdNumber
+	}
+
+	if msg.Params != nil {
+		params, err := protojson.Marshal(msg.Params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal params: %w", err)
+		}
+		base.Params = params
+	}
+
+	if msg.Result != nil {
+		result, err := protojson.Marshal(msg.Result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		base.Result = result
+	}
+
+	if msg.Error != nil {
+		base.Error = &types.RPCError{
+			Code:    int(msg.Error.Code),
+			Message: msg.Error.Message,
+		}
+		if msg.Error.Data != nil {
+			base.Error.Data = msg.Error.Data.AsInterface()
+		}
+	}
+
+	return base, nil
+}
@@ -0,0 +1,51 @@
+package grpcgateway
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// httpStatus maps a gRPC status code to the HTTP status the gateway
+// responds with. Codes without an obvious HTTP equivalent fall back to 500,
+// matching how a generic internal error is reported elsewhere in the repo.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// jsonError is the {"error":...,"code":...} body every failed gateway
+// request responds with. Code is the HTTP status also set on the response,
+// repeated in the body so a caller parsing only the JSON (e.g. from a
+// browser fetch() that already consumed the status) still sees it.
+type jsonError struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// writeJSONError writes status and err as a jsonError body.
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, jsonError{Error: err.Error(), Code: status})
+}
+
+// writeJSON writes v as the JSON response body with status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,29 @@
+package grpcgateway
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.NotFound, http.StatusNotFound},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.Internal, http.StatusInternalServerError},
+		{codes.Unknown, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := httpStatus(tt.code); got != tt.want {
+			t.Errorf("httpStatus(%v) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
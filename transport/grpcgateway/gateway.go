@@ -0,0 +1,221 @@
+// Package grpcgateway exposes a gRPC MCP server - whether grpc.ServerTransport
+// running in this process (dial "localhost:<port>") or a remote one - over
+// plain HTTP+JSON, in the spirit of grpc-gateway. It lets browsers, curl, and
+// other non-gRPC clients talk to a gRPC-transport MCP server without a second
+// server implementation.
+//
+// Each HTTP request opens its own short-lived gRPC stream against target,
+// sends the translated JSON-RPC message, and waits for the one correlated
+// response before closing it. That keeps request/response correlation
+// trivial at the cost of a stream setup per call; a client that needs
+// persistent-connection performance should talk gRPC (or one of the other
+// transports) directly instead.
+package grpcgateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DR1N0/mcp-go/transport"
+	pb "github.com/DR1N0/mcp-go/transport/grpc/protogen"
+	"github.com/DR1N0/mcp-go/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// defaultTimeout bounds how long a gateway request waits for its
+// correlated response before the call is abandoned with
+// codes.DeadlineExceeded.
+const defaultTimeout = 30 * time.Second
+
+// Gateway bridges plain HTTP+JSON requests to a gRPC MCP server.
+type Gateway struct {
+	addr     string
+	target   string
+	dialOpts []grpc.DialOption
+	timeout  time.Duration
+
+	server       *http.Server
+	conn         *grpc.ClientConn
+	client       pb.JSONRPCServiceClient
+	errorHandler transport.ErrorHandler
+}
+
+// Option configures a Gateway.
+type Option func(*Gateway)
+
+// WithDialOptions adds gRPC dial options used to connect to target, e.g.
+// credentials.TransportCredentials for a TLS-terminated upstream.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(g *Gateway) {
+		g.dialOpts = append(g.dialOpts, opts...)
+	}
+}
+
+// WithTimeout bounds how long a gateway request waits for its correlated
+// response before failing with a 504. The default is 30 seconds.
+func WithTimeout(d time.Duration) Option {
+	return func(g *Gateway) {
+		g.timeout = d
+	}
+}
+
+// NewGateway creates a Gateway listening on addr that bridges to the gRPC
+// MCP server at target.
+func NewGateway(addr, target string, opts ...Option) *Gateway {
+	g := &Gateway{
+		addr:     addr,
+		target:   target,
+		dialOpts: []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		timeout:  defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// SetErrorHandler sets the callback for errors the gateway can't attribute
+// to a single HTTP request, e.g. a failure serving the HTTP listener.
+func (g *Gateway) SetErrorHandler(handler transport.ErrorHandler) {
+	g.errorHandler = handler
+}
+
+// Start dials target and begins serving HTTP on addr.
+func (g *Gateway) Start(ctx context.Context) error {
+	conn, err := grpc.NewClient(g.target, g.dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to dial %s: %w", g.target, err)
+	}
+	g.conn = conn
+	g.client = pb.NewJSONRPCServiceClient(conn)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /mcp", g.handleJSONRPC)
+	mux.HandleFunc("GET /mcp/tools", g.handleList("tools/list"))
+	mux.HandleFunc("GET /mcp/resources", g.handleList("resources/list"))
+	mux.HandleFunc("GET /mcp/prompts", g.handleList("prompts/list"))
+
+	g.server = &http.Server{
+		Addr:    g.addr,
+		Handler: mux,
+	}
+
+	go func() {
+		if err := g.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			if g.errorHandler != nil {
+				g.errorHandler(fmt.Errorf("grpcgateway server error: %w", err))
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close shuts down the HTTP listener and the upstream gRPC connection.
+func (g *Gateway) Close() error {
+	if g.server != nil {
+		if err := g.server.Shutdown(context.Background()); err != nil {
+			return fmt.Errorf("failed to shut down gateway server: %w", err)
+		}
+	}
+	if g.conn != nil {
+		return g.conn.Close()
+	}
+	return nil
+}
+
+// handleJSONRPC implements POST /mcp: the request body is a single JSON-RPC
+// message, forwarded to target and answered with its correlated response.
+func (g *Gateway) handleJSONRPC(w http.ResponseWriter, r *http.Request) {
+	var msg types.BaseJSONRPCMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON-RPC body: %w", err))
+		return
+	}
+
+	resp, err := g.call(r.Context(), &msg)
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleList returns a handler for one of the GET REST-style shortcuts: it
+// issues method with no params and responds with just the result, so a
+// browser or curl doesn't need to speak JSON-RPC envelopes at all.
+func (g *Gateway) handleList(method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &types.BaseJSONRPCMessage{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  method,
+			Params:  json.RawMessage(`{}`),
+		}
+
+		resp, err := g.call(r.Context(), req)
+		if err != nil {
+			writeStatusError(w, err)
+			return
+		}
+		if resp.Error != nil {
+			writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("%s: %s", method, resp.Error.Message))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(resp.Result)
+	}
+}
+
+// call opens a stream against target, sends msg, and returns the one
+// response correlated to it, bounded by g.timeout.
+func (g *Gateway) call(ctx context.Context, msg *types.BaseJSONRPCMessage) (*types.BaseJSONRPCMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	stream, err := g.client.Transport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+
+	protoMsg, err := baseToProto(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert request: %w", err)
+	}
+	if err := stream.Send(protoMsg); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	for {
+		protoResp, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive response: %w", err)
+		}
+
+		resp, err := protoToBase(protoResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert response: %w", err)
+		}
+		// Skip server-initiated messages (requests and notifications carry a
+		// method) and responses to other ids.
+		if resp.Method == "" && fmt.Sprint(resp.ID) == fmt.Sprint(msg.ID) {
+			return resp, nil
+		}
+	}
+}
+
+// writeStatusError maps err's gRPC status code to an HTTP status and writes
+// it as the {"error":...,"code":...} body; err that carries no gRPC status
+// (e.g. a local conversion failure) is reported as 500.
+func writeStatusError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeJSONError(w, httpStatus(st.Code()), fmt.Errorf("%s", st.Message()))
+}
@@ -9,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +18,10 @@ import (
 	"github.com/DR1N0/mcp-go/types"
 )
 
+// defaultReconnectDelay is used between reconnect attempts until the server
+// sends an SSE retry: field suggesting a different delay.
+const defaultReconnectDelay = 1 * time.Second
+
 // sseClientTransport implements SSE transport for MCP clients
 type sseClientTransport struct {
 	sseURL         string
@@ -30,6 +36,11 @@ type sseClientTransport struct {
 	closed         bool
 	timeout        time.Duration
 	endpointReady  chan struct{} // Signals when endpoint URL is received
+
+	resume      bool
+	sessionID   string
+	lastEventID uint64
+	retryDelay  time.Duration
 }
 
 // ClientTransportOption configures the client transport
@@ -42,6 +53,17 @@ func WithTimeout(timeout time.Duration) ClientTransportOption {
 	}
 }
 
+// WithResume enables automatic resumption after transient network failures:
+// the transport remembers the session_id from the server's endpoint event and
+// the ID of the last processed message event, then replays both (as a
+// session_id query parameter and a Last-Event-ID header) on reconnect so the
+// server can replay anything missed while disconnected.
+func WithResume(enabled bool) ClientTransportOption {
+	return func(t *sseClientTransport) {
+		t.resume = enabled
+	}
+}
+
 // NewClientTransport creates a new SSE client transport
 // sseURL is the SSE endpoint URL (e.g., "http://localhost:8001/mcp/sse")
 func NewClientTransport(sseURL string, opts ...ClientTransportOption) ClientTransport {
@@ -107,11 +129,12 @@ func (t *sseClientTransport) connectSSE() {
 					errorHandler(fmt.Errorf("SSE connection error: %w", err))
 				}
 
-				// Wait before reconnecting
+				// Wait before reconnecting, honoring any server-suggested
+				// delay from a prior retry: field
 				select {
 				case <-t.ctx.Done():
 					return
-				case <-time.After(1 * time.Second):
+				case <-time.After(t.reconnectDelay()):
 					continue
 				}
 			}
@@ -121,7 +144,12 @@ func (t *sseClientTransport) connectSSE() {
 
 // listenToSSE connects to SSE endpoint and processes events
 func (t *sseClientTransport) listenToSSE() error {
-	req, err := http.NewRequestWithContext(t.ctx, http.MethodGet, t.sseURL, nil)
+	reqURL, err := t.resumeURL()
+	if err != nil {
+		return fmt.Errorf("failed to build request URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(t.ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -129,6 +157,13 @@ func (t *sseClientTransport) listenToSSE() error {
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
 
+	t.mu.RLock()
+	resume, lastEventID := t.resume, t.lastEventID
+	t.mu.RUnlock()
+	if resume && lastEventID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+	}
+
 	resp, err := t.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
@@ -143,6 +178,7 @@ func (t *sseClientTransport) listenToSSE() error {
 	reader := bufio.NewReader(resp.Body)
 	var dataBuffer bytes.Buffer
 	var eventType string
+	var eventID string
 
 	for {
 		line, err := reader.ReadString('\n')
@@ -162,7 +198,7 @@ func (t *sseClientTransport) listenToSSE() error {
 				if eventType == "endpoint" {
 					t.handleEndpointEvent(dataBuffer.Bytes())
 				} else if eventType == "message" {
-					if err := t.processEvent(dataBuffer.Bytes()); err != nil {
+					if err := t.processEventOrBatch(dataBuffer.Bytes()); err != nil {
 						t.mu.RLock()
 						errorHandler := t.errorHandler
 						t.mu.RUnlock()
@@ -170,10 +206,12 @@ func (t *sseClientTransport) listenToSSE() error {
 							errorHandler(fmt.Errorf("failed to process event: %w", err))
 						}
 					}
+					t.recordEventID(eventID)
 				}
 				dataBuffer.Reset()
 				eventType = ""
 			}
+			eventID = ""
 			continue
 		}
 
@@ -184,10 +222,75 @@ func (t *sseClientTransport) listenToSSE() error {
 			data := strings.TrimPrefix(line, "data:")
 			data = strings.TrimSpace(data)
 			dataBuffer.WriteString(data)
+		} else if strings.HasPrefix(line, "id:") {
+			eventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		} else if strings.HasPrefix(line, "retry:") {
+			t.recordRetryDelay(strings.TrimSpace(strings.TrimPrefix(line, "retry:")))
 		}
 	}
 }
 
+// recordEventID remembers the ID of the last processed message event so it
+// can be sent as Last-Event-ID if the connection drops and WithResume is set
+func (t *sseClientTransport) recordEventID(eventID string) {
+	if eventID == "" {
+		return
+	}
+	id, err := strconv.ParseUint(eventID, 10, 64)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	t.lastEventID = id
+	t.mu.Unlock()
+}
+
+// recordRetryDelay remembers a server-suggested reconnect delay from an SSE
+// retry: field, taking effect on the next reconnect and every one after
+// until a new value arrives.
+func (t *sseClientTransport) recordRetryDelay(raw string) {
+	ms, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	t.retryDelay = time.Duration(ms) * time.Millisecond
+	t.mu.Unlock()
+}
+
+// reconnectDelay returns the most recently received retry: delay, or
+// defaultReconnectDelay if the server has never sent one.
+func (t *sseClientTransport) reconnectDelay() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.retryDelay > 0 {
+		return t.retryDelay
+	}
+	return defaultReconnectDelay
+}
+
+// resumeURL returns the SSE request URL, adding a session_id query parameter
+// when WithResume is set and a prior endpoint event has supplied one, so a
+// reconnect re-attaches to the same server-side session.
+func (t *sseClientTransport) resumeURL() (string, error) {
+	t.mu.RLock()
+	resume, sessionID := t.resume, t.sessionID
+	t.mu.RUnlock()
+
+	if !resume || sessionID == "" {
+		return t.sseURL, nil
+	}
+
+	u, err := url.Parse(t.sseURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("session_id", sessionID)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
 // handleEndpointEvent processes the endpoint event from the server
 func (t *sseClientTransport) handleEndpointEvent(data []byte) {
 	endpoint := strings.TrimSpace(string(data))
@@ -204,6 +307,11 @@ func (t *sseClientTransport) handleEndpointEvent(data []byte) {
 
 	t.mu.Lock()
 	t.messageURL = messageURL
+	if u, err := url.Parse(messageURL); err == nil {
+		if sessionID := u.Query().Get("session_id"); sessionID != "" {
+			t.sessionID = sessionID
+		}
+	}
 	t.mu.Unlock()
 
 	// Signal that endpoint is ready
@@ -214,17 +322,31 @@ func (t *sseClientTransport) handleEndpointEvent(data []byte) {
 	}
 }
 
-// processEvent processes an SSE event containing JSON-RPC message
-func (t *sseClientTransport) processEvent(data []byte) error {
+// processEventOrBatch processes an SSE event containing either a single
+// JSON-RPC message or a JSON-RPC batch (a top-level JSON array)
+func (t *sseClientTransport) processEventOrBatch(data []byte) error {
+	t.mu.RLock()
+	messageHandler := t.messageHandler
+	t.mu.RUnlock()
+
+	if isJSONArray(data) {
+		var batch []*types.BaseJSONRPCMessage
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return fmt.Errorf("failed to parse JSON batch: %w", err)
+		}
+		if messageHandler != nil {
+			for _, msg := range batch {
+				messageHandler(t.ctx, msg)
+			}
+		}
+		return nil
+	}
+
 	var msg types.BaseJSONRPCMessage
 	if err := json.Unmarshal(data, &msg); err != nil {
 		return fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	t.mu.RLock()
-	messageHandler := t.messageHandler
-	t.mu.RUnlock()
-
 	if messageHandler != nil {
 		messageHandler(t.ctx, &msg)
 	}
@@ -232,6 +354,22 @@ func (t *sseClientTransport) processEvent(data []byte) error {
 	return nil
 }
 
+// isJSONArray reports whether data's first non-whitespace byte opens a
+// JSON array, i.e. it carries a JSON-RPC batch rather than a single message.
+func isJSONArray(data []byte) bool {
+	for _, b := range data {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
 // Send sends a JSON-RPC message to the server via POST
 func (t *sseClientTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMessage) error {
 	// Wait for messageURL to be set (poll with timeout)
@@ -292,6 +430,59 @@ func (t *sseClientTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMes
 	return nil
 }
 
+// SendBatch sends a JSON-RPC batch to the server via a single POST request
+func (t *sseClientTransport) SendBatch(ctx context.Context, msgs []*types.BaseJSONRPCMessage) error {
+	var messageURL string
+	deadline := time.Now().Add(5 * time.Second)
+
+	for time.Now().Before(deadline) {
+		t.mu.RLock()
+		messageURL = t.messageURL
+		closed := t.closed
+		t.mu.RUnlock()
+
+		if closed {
+			return fmt.Errorf("transport is closed")
+		}
+		if messageURL != "" {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if messageURL == "" {
+		return fmt.Errorf("timeout waiting for endpoint URL from server")
+	}
+
+	data, err := json.Marshal(msgs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, messageURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 // Close shuts down the transport
 func (t *sseClientTransport) Close() error {
 	t.mu.Lock()
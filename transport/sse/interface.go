@@ -1,6 +1,8 @@
 package sse
 
 import (
+	"net/netip"
+
 	"github.com/DR1N0/mcp-go/transport"
 	"github.com/DR1N0/mcp-go/types"
 )
@@ -10,6 +12,19 @@ type ServerTransport interface {
 	transport.ServerTransport
 	// WithMiddleware adds HTTP middleware to be chained before the MCP handler
 	WithMiddleware(middleware ...types.HTTPMiddleware) ServerTransport
+	// WithTrustedProxies sets the address ranges allowed to report a client
+	// IP via the configured forwarded header (see WithForwardedHeader)
+	WithTrustedProxies(proxies []netip.Prefix) ServerTransport
+	// WithForwardedHeader selects which forwarding header to trust:
+	// "X-Forwarded-For" (default), "X-Real-IP", or "Forwarded"
+	WithForwardedHeader(header string) ServerTransport
+	// WithTrustHops caps how many proxy hops are skipped when walking the
+	// forwarded header; zero (the default) walks until an untrusted hop
+	WithTrustHops(hops int) ServerTransport
+	// WithSessionHandler registers a SessionHandler invoked once per new
+	// session, returning the MessageHandler (and optional CloseHandler)
+	// that handles messages for that session from then on
+	WithSessionHandler(handler SessionHandler) ServerTransport
 }
 
 // ClientTransport is an SSE transport for MCP clients
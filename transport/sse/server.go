@@ -6,54 +6,155 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/netip"
+	"strconv"
 	"sync"
 
 	"github.com/DR1N0/mcp-go/transport"
 	"github.com/DR1N0/mcp-go/types"
 )
 
-// sseSession represents a single SSE client session
+// defaultEventBufferSize is how many recent messages a session keeps for
+// Last-Event-ID replay when NewServerTransport isn't given WithEventBufferSize
+const defaultEventBufferSize = 100
+
+// sseBufferedEvent is one message recorded in a session's replay buffer,
+// keyed by a monotonically increasing per-session event ID
+type sseBufferedEvent struct {
+	id   uint64
+	data []byte
+}
+
+// sseSession represents a single SSE client session. It outlives any
+// individual SSE connection: messageChan and the streaming loop are
+// recreated on reconnect, but the event buffer and request processing
+// goroutine persist so a client can resume with Last-Event-ID.
 type sseSession struct {
 	id             string
-	messageChan    chan []byte
+	messageChan    chan sseBufferedEvent
 	requestChan    chan *types.BaseJSONRPCMessage
 	ctx            context.Context
 	cancel         context.CancelFunc
 	messageHandler transport.MessageHandler
+	closeHandler   transport.CloseHandler
+	remoteAddr     string
+	header         http.Header
+	values         SessionValues
+
+	mu          sync.Mutex
+	nextEventID uint64
+	buffer      []sseBufferedEvent
+	bufferSize  int
+	dispatchCtx context.Context
+}
+
+// enqueue assigns the next event ID to data, records it in the replay
+// buffer (trimming to bufferSize), and delivers it to the session's current
+// SSE connection if one is attached and keeping up.
+func (s *sseSession) enqueue(data []byte) error {
+	s.mu.Lock()
+	s.nextEventID++
+	ev := sseBufferedEvent{id: s.nextEventID, data: data}
+	s.buffer = append(s.buffer, ev)
+	if len(s.buffer) > s.bufferSize {
+		s.buffer = s.buffer[len(s.buffer)-s.bufferSize:]
+	}
+	ch := s.messageChan
+	s.mu.Unlock()
+
+	select {
+	case ch <- ev:
+		return nil
+	default:
+		return fmt.Errorf("session buffer full")
+	}
+}
+
+// eventsAfter returns buffered events with an ID greater than lastEventID,
+// in order, for replay on reconnect.
+func (s *sseSession) eventsAfter(lastEventID uint64) []sseBufferedEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var replay []sseBufferedEvent
+	for _, ev := range s.buffer {
+		if ev.id > lastEventID {
+			replay = append(replay, ev)
+		}
+	}
+	return replay
+}
+
+// evicted reports whether lastEventID refers to an event that has already
+// aged out of the replay buffer, meaning eventsAfter can no longer replay
+// a gap-free stream from that point. A lastEventID of 0 (no cursor) is
+// never evicted.
+func (s *sseSession) evicted(lastEventID uint64) bool {
+	if lastEventID == 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.buffer) == 0 {
+		return true
+	}
+	return lastEventID < s.buffer[0].id-1
 }
 
 // sseServerTransport implements SSE transport for MCP servers
 type sseServerTransport struct {
-	sseEndpoint    string
-	messageHandler transport.MessageHandler
-	errorHandler   transport.ErrorHandler
-	closeHandler   transport.CloseHandler
-	server         *http.Server
-	middleware     []transport.HTTPMiddleware
-	mu             sync.RWMutex
-	sessions       map[string]*sseSession
-	ctx            context.Context
-	cancel         context.CancelFunc
-	closed         bool
+	sseEndpoint     string
+	messageHandler  transport.MessageHandler
+	errorHandler    transport.ErrorHandler
+	closeHandler    transport.CloseHandler
+	sessionHandler  SessionHandler
+	server          *http.Server
+	middleware      []transport.HTTPMiddleware
+	mu              sync.RWMutex
+	sessions        map[string]*sseSession
+	ctx             context.Context
+	cancel          context.CancelFunc
+	closed          bool
+	clientIPConfig  transport.ClientIPConfig
+	eventBufferSize int
+}
+
+// ServerOption configures the SSE server transport at construction time
+type ServerOption func(*sseServerTransport)
+
+// WithEventBufferSize sets how many recent messages each session keeps for
+// Last-Event-ID replay after a reconnect. The default is defaultEventBufferSize.
+func WithEventBufferSize(n int) ServerOption {
+	return func(t *sseServerTransport) {
+		t.eventBufferSize = n
+	}
 }
 
 // NewServerTransport creates a new SSE server transport
 // sseEndpoint is the path for SSE streaming (e.g., "/mcp/sse")
 // addr is the server address (e.g., ":8001")
-func NewServerTransport(sseEndpoint string, addr string) ServerTransport {
+func NewServerTransport(sseEndpoint string, addr string, opts ...ServerOption) ServerTransport {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &sseServerTransport{
-		sseEndpoint: sseEndpoint,
-		sessions:    make(map[string]*sseSession),
-		middleware:  make([]transport.HTTPMiddleware, 0),
-		ctx:         ctx,
-		cancel:      cancel,
-		closed:      false,
+	t := &sseServerTransport{
+		sseEndpoint:     sseEndpoint,
+		sessions:        make(map[string]*sseSession),
+		middleware:      make([]transport.HTTPMiddleware, 0),
+		ctx:             ctx,
+		cancel:          cancel,
+		closed:          false,
+		eventBufferSize: defaultEventBufferSize,
 		server: &http.Server{
 			Addr: addr,
 		},
 	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
 }
 
 // WithMiddleware adds HTTP middleware to be chained before the MCP handler
@@ -63,6 +164,40 @@ func (t *sseServerTransport) WithMiddleware(middleware ...transport.HTTPMiddlewa
 	return t
 }
 
+// WithTrustedProxies sets the address ranges allowed to report a client IP
+// via the configured forwarded header
+func (t *sseServerTransport) WithTrustedProxies(proxies []netip.Prefix) ServerTransport {
+	t.clientIPConfig.TrustedProxies = proxies
+	return t
+}
+
+// WithForwardedHeader selects which forwarding header to trust:
+// "X-Forwarded-For" (default), "X-Real-IP", or "Forwarded"
+func (t *sseServerTransport) WithForwardedHeader(header string) ServerTransport {
+	t.clientIPConfig.ForwardedHeader = header
+	return t
+}
+
+// WithTrustHops caps how many proxy hops are skipped when walking the
+// forwarded header; zero (the default) walks until an untrusted hop
+func (t *sseServerTransport) WithTrustHops(hops int) ServerTransport {
+	t.clientIPConfig.TrustHops = hops
+	return t
+}
+
+// WithSessionHandler registers handler to be invoked once, at connect
+// time, for each newly created session. When set, its returned
+// MessageHandler and CloseHandler take over dispatch for that session in
+// place of the transport-level SetMessageHandler/SetCloseHandler, which
+// remain as the fallback for sessions created before a handler is
+// registered, or when none is registered at all.
+func (t *sseServerTransport) WithSessionHandler(handler SessionHandler) ServerTransport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessionHandler = handler
+	return t
+}
+
 // generateSessionID creates a new random session ID
 func generateSessionID() string {
 	b := make([]byte, 16)
@@ -136,7 +271,14 @@ func (t *sseServerTransport) Start(ctx context.Context) error {
 	return nil
 }
 
-// handleSSE handles SSE connections from clients
+// handleSSE handles SSE connections from clients. If the request carries a
+// known session_id, the existing session - and its event replay buffer - is
+// reattached rather than allocating a fresh one; a Last-Event-ID (header or
+// ?last_event_id= query) then triggers replay of any buffered events with a
+// higher ID before the connection resumes live streaming. If the requested
+// Last-Event-ID names an unknown session or one that has aged out of the
+// replay buffer, handleSSE responds 404 instead of silently dropping the
+// gap, so the client knows to re-initialize rather than resume.
 func (t *sseServerTransport) handleSSE(w http.ResponseWriter, r *http.Request) {
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
@@ -144,34 +286,59 @@ func (t *sseServerTransport) handleSSE(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 
-	// Create new session
-	sessionID := generateSessionID()
-	sessionCtx, sessionCancel := context.WithCancel(r.Context())
-
-	session := &sseSession{
-		id:             sessionID,
-		messageChan:    make(chan []byte, 10),
-		requestChan:    make(chan *types.BaseJSONRPCMessage, 10),
-		ctx:            sessionCtx,
-		cancel:         sessionCancel,
-		messageHandler: nil, // Will be set when needed
+	baseCtx := r.Context()
+	if addr, ok := transport.ResolveClientIP(r.RemoteAddr, r.Header, t.clientIPConfig); ok {
+		baseCtx = types.WithClientIP(baseCtx, addr)
 	}
 
-	// Register session
-	t.mu.Lock()
-	t.sessions[sessionID] = session
-	session.messageHandler = t.messageHandler
-	t.mu.Unlock()
+	sessionID := r.URL.Query().Get("session_id")
+	lastEventID, hasLastEventID := parseLastEventID(r)
 
-	// Deregister session on disconnect
-	defer func() {
-		sessionCancel()
-		t.mu.Lock()
-		delete(t.sessions, sessionID)
-		close(session.messageChan)
-		close(session.requestChan)
+	t.mu.Lock()
+	session, resumed := t.sessions[sessionID]
+	if sessionID != "" && !resumed && hasLastEventID {
 		t.mu.Unlock()
-	}()
+		http.Error(w, "unknown session; cannot resume from Last-Event-ID", http.StatusNotFound)
+		return
+	}
+	if resumed && hasLastEventID && session.evicted(lastEventID) {
+		t.mu.Unlock()
+		http.Error(w, "Last-Event-ID has aged out of the replay buffer", http.StatusNotFound)
+		return
+	}
+	if sessionID == "" || !resumed {
+		sessionID = generateSessionID()
+		sessionCtx, sessionCancel := context.WithCancel(t.ctx)
+		session = &sseSession{
+			id:          sessionID,
+			requestChan: make(chan *types.BaseJSONRPCMessage, 10),
+			ctx:         sessionCtx,
+			cancel:      sessionCancel,
+			bufferSize:  t.eventBufferSize,
+			remoteAddr:  r.RemoteAddr,
+			header:      r.Header.Clone(),
+		}
+		if t.sessionHandler != nil {
+			session.messageHandler, session.closeHandler = t.sessionHandler(&Session{session: session})
+		} else {
+			session.messageHandler = t.messageHandler
+			session.closeHandler = t.closeHandler
+		}
+		t.sessions[sessionID] = session
+		resumed = false
+
+		go func(s *sseSession) {
+			<-s.ctx.Done()
+			if s.closeHandler != nil {
+				s.closeHandler()
+			}
+		}(session)
+	}
+	session.mu.Lock()
+	session.messageChan = make(chan sseBufferedEvent, 10)
+	session.dispatchCtx = baseCtx
+	session.mu.Unlock()
+	t.mu.Unlock()
 
 	// Get flusher
 	flusher, ok := w.(http.Flusher)
@@ -191,8 +358,20 @@ func (t *sseServerTransport) handleSSE(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "data: %s\n\n", messageEndpoint)
 	flusher.Flush()
 
-	// Start message processor for this session
-	go t.processSessionMessages(session)
+	// Start the message processor the first time this session is created;
+	// it keeps running across reconnects since it's tied to session.ctx.
+	if !resumed {
+		go t.processSessionMessages(session)
+	}
+
+	if hasLastEventID {
+		for _, ev := range session.eventsAfter(lastEventID) {
+			fmt.Fprintf(w, "id: %d\n", ev.id)
+			fmt.Fprintf(w, "event: message\n")
+			fmt.Fprintf(w, "data: %s\n\n", ev.data)
+		}
+		flusher.Flush()
+	}
 
 	// Stream events to client
 	for {
@@ -201,15 +380,36 @@ func (t *sseServerTransport) handleSSE(w http.ResponseWriter, r *http.Request) {
 			return
 		case <-session.ctx.Done():
 			return
-		case data := <-session.messageChan:
+		case <-r.Context().Done():
+			return
+		case ev := <-session.messageChan:
 			// Write SSE event
+			fmt.Fprintf(w, "id: %d\n", ev.id)
 			fmt.Fprintf(w, "event: message\n")
-			fmt.Fprintf(w, "data: %s\n\n", data)
+			fmt.Fprintf(w, "data: %s\n\n", ev.data)
 			flusher.Flush()
 		}
 	}
 }
 
+// parseLastEventID extracts the client's last received event ID from the
+// Last-Event-ID header, falling back to a ?last_event_id= query parameter.
+// The second return value is false if neither was present or valid.
+func parseLastEventID(r *http.Request) (uint64, bool) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
 // processSessionMessages processes incoming messages for a session
 func (t *sseServerTransport) processSessionMessages(session *sseSession) {
 	for {
@@ -218,8 +418,10 @@ func (t *sseServerTransport) processSessionMessages(session *sseSession) {
 			return
 		case msg := <-session.requestChan:
 			if session.messageHandler != nil {
-				// Create context with session ID
-				ctx := context.WithValue(session.ctx, "session_id", session.id)
+				session.mu.Lock()
+				dispatchCtx := session.dispatchCtx
+				session.mu.Unlock()
+				ctx := withSession(dispatchCtx, &Session{session: session})
 				session.messageHandler(ctx, msg)
 			}
 		}
@@ -245,20 +447,84 @@ func (t *sseServerTransport) handleMessage(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Parse JSON-RPC message
-	var msg types.BaseJSONRPCMessage
-	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	// Read the raw body so we can tell a single message apart from a batch
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
 		return
 	}
 
-	// Send to session's request channel
-	select {
-	case session.requestChan <- &msg:
-		w.WriteHeader(http.StatusAccepted)
-	case <-session.ctx.Done():
-		http.Error(w, "Session closed", http.StatusGone)
+	var msgs []*types.BaseJSONRPCMessage
+	if isJSONArray(body) {
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			http.Error(w, "Invalid JSON batch", http.StatusBadRequest)
+			return
+		}
+	} else {
+		var msg types.BaseJSONRPCMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		msgs = []*types.BaseJSONRPCMessage{&msg}
+	}
+
+	// Send each message to the session's request channel; the session
+	// processor dispatches them to the handler one at a time.
+	for _, msg := range msgs {
+		select {
+		case session.requestChan <- msg:
+		case <-session.ctx.Done():
+			http.Error(w, "Session closed", http.StatusGone)
+			return
+		}
 	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// isJSONArray reports whether body's first non-whitespace byte opens a
+// JSON array, i.e. it carries a JSON-RPC batch rather than a single message.
+func isJSONArray(body []byte) bool {
+	for _, b := range body {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// SendBatch writes a JSON-RPC batch as a single SSE "message" event
+func (t *sseServerTransport) SendBatch(ctx context.Context, msgs []*types.BaseJSONRPCMessage) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed {
+		return fmt.Errorf("transport is closed")
+	}
+
+	data, err := json.Marshal(msgs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	if session, ok := SessionFromContext(ctx); ok {
+		if s, exists := t.sessions[session.ID()]; exists {
+			return s.enqueue(data)
+		}
+		return fmt.Errorf("session not found: %s", session.ID())
+	}
+
+	for _, session := range t.sessions {
+		session.enqueue(data)
+	}
+
+	return nil
 }
 
 // Send sends a message to the appropriate SSE client based on context
@@ -276,28 +542,17 @@ func (t *sseServerTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMes
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	// Try to get session ID from context
-	sessionID, ok := ctx.Value("session_id").(string)
-	if ok && sessionID != "" {
-		// Send to specific session
-		if session, exists := t.sessions[sessionID]; exists {
-			select {
-			case session.messageChan <- data:
-				return nil
-			default:
-				return fmt.Errorf("session buffer full")
-			}
+	// Send to the originating session, if ctx carries one
+	if session, ok := SessionFromContext(ctx); ok {
+		if s, exists := t.sessions[session.ID()]; exists {
+			return s.enqueue(data)
 		}
-		return fmt.Errorf("session not found: %s", sessionID)
+		return fmt.Errorf("session not found: %s", session.ID())
 	}
 
 	// Fallback: broadcast to all sessions (for notifications)
 	for _, session := range t.sessions {
-		select {
-		case session.messageChan <- data:
-		default:
-			// Session buffer full, skip
-		}
+		session.enqueue(data)
 	}
 
 	return nil
@@ -0,0 +1,106 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/DR1N0/mcp-go/transport"
+	"github.com/DR1N0/mcp-go/types"
+)
+
+// Session is a handle to a single SSE client session, valid for the
+// lifetime of that session (which outlives any one SSE connection - see
+// sseSession). It gives tool and notification code typed access to
+// per-connection state that used to be carried through an untyped
+// context.WithValue("session_id", ...) string.
+type Session struct {
+	session *sseSession
+}
+
+// ID returns the session's unique ID, as sent in the "endpoint" SSE event
+// and expected back as the ?session_id= query parameter on the message
+// endpoint.
+func (s *Session) ID() string {
+	return s.session.id
+}
+
+// RemoteAddr returns the remote address of the connection that created
+// this session.
+func (s *Session) RemoteAddr() string {
+	return s.session.remoteAddr
+}
+
+// Header returns the HTTP headers sent on the connection that created
+// this session.
+func (s *Session) Header() http.Header {
+	return s.session.header
+}
+
+// Values returns the session's scratchpad for per-connection state, e.g.
+// an auth principal or negotiated capabilities, set by the SessionHandler
+// and read back by later tool or notification code.
+func (s *Session) Values() *SessionValues {
+	return &s.session.values
+}
+
+// Send enqueues msg for delivery to this session's SSE connection,
+// buffering it for Last-Event-ID replay like any other outgoing message.
+func (s *Session) Send(msg *types.BaseJSONRPCMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.session.enqueue(data)
+}
+
+// SessionValues is a per-session scratchpad for arbitrary values, safe for
+// concurrent use by both the SessionHandler and concurrent message
+// dispatch.
+type SessionValues struct {
+	mu   sync.Mutex
+	data map[string]interface{}
+}
+
+// Get returns the value stored under key, or nil if none was set.
+func (v *SessionValues) Get(key string) interface{} {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.data[key]
+}
+
+// Set stores value under key.
+func (v *SessionValues) Set(key string, value interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.data == nil {
+		v.data = make(map[string]interface{})
+	}
+	v.data[key] = value
+}
+
+// SessionHandler is invoked once, at connect time, for each newly created
+// session. It returns the MessageHandler that processes messages arriving
+// on that session, and an optional CloseHandler invoked when the session
+// ends. Either handler may be nil.
+type SessionHandler func(session *Session) (transport.MessageHandler, transport.CloseHandler)
+
+// sessionContextKey is the context key for the *Session threaded through
+// message dispatch, replacing the old untyped "session_id" string key.
+type sessionContextKey struct{}
+
+// withSession returns a copy of ctx carrying session.
+func withSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+// SessionFromContext returns the Session associated with ctx, if any. A
+// MessageHandler registered via SessionHandler, and any code it calls,
+// can use this to target server-initiated notifications and sampling
+// requests back at the session that originated the request being
+// handled.
+func SessionFromContext(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(sessionContextKey{}).(*Session)
+	return session, ok
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os/exec"
 	"sync"
+	"time"
 
 	"github.com/DR1N0/mcp-go/transport"
 	"github.com/DR1N0/mcp-go/types"
@@ -26,10 +27,18 @@ type stdioClientTransport struct {
 	messageHandler transport.MessageHandler
 	errorHandler   transport.ErrorHandler
 	closeHandler   transport.CloseHandler
+	restartHandler func(attempt int, err error)
+	framing        Framing
+	frameReader    frameReader
 	mu             sync.RWMutex
 	ctx            context.Context
 	cancel         context.CancelFunc
 	closed         bool
+
+	supervised    bool
+	restartPolicy RestartPolicy
+	restarting    bool
+	restartErr    error
 }
 
 // ClientTransportOption configures the client transport
@@ -42,6 +51,29 @@ func WithStderr(redirect bool) ClientTransportOption {
 	}
 }
 
+// WithFraming selects the wire framing used to read and write JSON-RPC
+// messages. It defaults to FramingNDJSON; the server must be configured
+// with the same framing via stdio.WithServerFraming.
+func WithFraming(framing Framing) ClientTransportOption {
+	return func(t *stdioClientTransport) {
+		t.framing = framing
+	}
+}
+
+// WithSupervisor enables supervised subprocess mode: on an unexpected exit
+// (non-zero status, or a read/EOF error while the transport hasn't been
+// explicitly closed), the command is respawned per policy, its pipes
+// re-opened, and reading resumes transparently. Send and SendBatch fail
+// with *ErrServerRestarted for any message that arrives while a respawn is
+// underway. The caller should register SetRestartHandler to re-run the MCP
+// handshake and restore any server-side state once a respawn succeeds.
+func WithSupervisor(policy RestartPolicy) ClientTransportOption {
+	return func(t *stdioClientTransport) {
+		t.supervised = true
+		t.restartPolicy = policy
+	}
+}
+
 // NewClientTransport creates a new stdio client transport
 // command is the server executable path
 // args are command-line arguments for the server
@@ -59,6 +91,7 @@ func NewClientTransport(command string, args []string, opts ...ClientTransportOp
 	for _, opt := range opts {
 		opt(t)
 	}
+	t.frameReader = newFrameReader(t.framing)
 
 	return t
 }
@@ -66,16 +99,30 @@ func NewClientTransport(command string, args []string, opts ...ClientTransportOp
 // Start spawns the server process and begins communication
 func (t *stdioClientTransport) Start(ctx context.Context) error {
 	t.mu.Lock()
-	defer t.mu.Unlock()
-
 	if t.closed {
+		t.mu.Unlock()
 		return fmt.Errorf("transport is closed")
 	}
 
-	// Create command
+	err := t.spawnLocked()
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Start reading loops
+	go t.readLoop()
+	go t.readStderrLoop()
+
+	return nil
+}
+
+// spawnLocked creates the child process and its pipes, replacing any
+// previous ones; t.mu must be held by the caller. Used by both Start and a
+// supervised respawn.
+func (t *stdioClientTransport) spawnLocked() error {
 	t.cmd = exec.CommandContext(t.ctx, t.command, t.args...)
 
-	// Set up pipes
 	var err error
 	t.stdin, err = t.cmd.StdinPipe()
 	if err != nil {
@@ -101,13 +148,90 @@ func (t *stdioClientTransport) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start server process: %w", err)
 	}
 
-	// Start reading loops
-	go t.readLoop()
-	go t.readStderrLoop()
+	return nil
+}
 
+// respawn replaces the child process and its pipes, then restarts the
+// stderr reader against the new pipe. readLoop picks up the new reader on
+// its next iteration; it's the caller in this same goroutine, so there's no
+// concurrent access to t.reader to race with spawnLocked's reassignment.
+func (t *stdioClientTransport) respawn() error {
+	t.mu.Lock()
+	err := t.spawnLocked()
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	go t.readStderrLoop()
 	return nil
 }
 
+// trySupervisedRestart responds to an unexpected exit (detected from
+// readLoop) by reaping the old process and respawning it per
+// t.restartPolicy, with exponential backoff between attempts. It returns
+// false - leaving the caller to shut the transport down as before - if
+// supervision isn't enabled, the transport is already closed, or the
+// restart budget is exhausted.
+func (t *stdioClientTransport) trySupervisedRestart(triggerErr error) bool {
+	t.mu.Lock()
+	if !t.supervised || t.closed {
+		t.mu.Unlock()
+		return false
+	}
+	policy := t.restartPolicy
+	oldCmd := t.cmd
+	t.mu.Unlock()
+
+	if oldCmd != nil && oldCmd.Process != nil {
+		if err := oldCmd.Wait(); err != nil {
+			triggerErr = fmt.Errorf("server process exited: %w", err)
+		}
+	}
+
+	t.mu.Lock()
+	t.restarting = true
+	t.restartErr = triggerErr
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.restarting = false
+		t.mu.Unlock()
+	}()
+
+	for attempt := 1; policy.MaxRestarts <= 0 || attempt <= policy.MaxRestarts; attempt++ {
+		if delay := restartBackoff(policy, attempt-1); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-t.ctx.Done():
+				timer.Stop()
+				return false
+			case <-timer.C:
+			}
+		}
+
+		if err := t.respawn(); err != nil {
+			t.mu.RLock()
+			errorHandler := t.errorHandler
+			t.mu.RUnlock()
+			if errorHandler != nil {
+				errorHandler(fmt.Errorf("failed to respawn server process (attempt %d): %w", attempt, err))
+			}
+			continue
+		}
+
+		t.mu.RLock()
+		restartHandler := t.restartHandler
+		t.mu.RUnlock()
+		if restartHandler != nil {
+			restartHandler(attempt, triggerErr)
+		}
+		return true
+	}
+
+	return false
+}
+
 // readLoop continuously reads JSON-RPC messages from stdout
 func (t *stdioClientTransport) readLoop() {
 	for {
@@ -115,9 +239,12 @@ func (t *stdioClientTransport) readLoop() {
 		case <-t.ctx.Done():
 			return
 		default:
-			// Read line from stdout
-			line, err := t.reader.ReadBytes('\n')
+			// Read the next frame from stdout
+			line, err := t.frameReader.readFrame(t.reader)
 			if err != nil {
+				if t.trySupervisedRestart(err) {
+					continue
+				}
 				if err == io.EOF {
 					// Server closed stdout, shut down gracefully
 					t.Close()
@@ -132,23 +259,38 @@ func (t *stdioClientTransport) readLoop() {
 				continue
 			}
 
+			t.mu.RLock()
+			messageHandler := t.messageHandler
+			errorHandler := t.errorHandler
+			t.mu.RUnlock()
+
+			// A JSON-RPC batch arrives as a top-level array; dispatch each
+			// element through the handler individually.
+			if isJSONArray(line) {
+				var batch []*types.BaseJSONRPCMessage
+				if err := json.Unmarshal(line, &batch); err != nil {
+					if errorHandler != nil {
+						errorHandler(fmt.Errorf("failed to parse JSON batch: %w", err))
+					}
+					continue
+				}
+				if messageHandler != nil {
+					for _, msg := range batch {
+						messageHandler(t.ctx, msg)
+					}
+				}
+				continue
+			}
+
 			// Parse JSON-RPC message
 			var msg types.BaseJSONRPCMessage
 			if err := json.Unmarshal(line, &msg); err != nil {
-				t.mu.RLock()
-				errorHandler := t.errorHandler
-				t.mu.RUnlock()
 				if errorHandler != nil {
 					errorHandler(fmt.Errorf("failed to parse JSON: %w", err))
 				}
 				continue
 			}
 
-			// Deliver message to handler
-			t.mu.RLock()
-			messageHandler := t.messageHandler
-			t.mu.RUnlock()
-
 			if messageHandler != nil {
 				messageHandler(t.ctx, &msg)
 			}
@@ -183,6 +325,9 @@ func (t *stdioClientTransport) Send(ctx context.Context, msg *types.BaseJSONRPCM
 	if t.closed {
 		return fmt.Errorf("transport is closed")
 	}
+	if t.restarting {
+		return &ErrServerRestarted{Err: t.restartErr}
+	}
 
 	// Marshal message to JSON
 	data, err := json.Marshal(msg)
@@ -190,20 +335,31 @@ func (t *stdioClientTransport) Send(ctx context.Context, msg *types.BaseJSONRPCM
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	// Write to stdin with newline
-	if _, err := t.writer.Write(data); err != nil {
-		return fmt.Errorf("failed to write to stdin: %w", err)
+	if err := writeFrame(t.writer, data, t.framing); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendBatch writes a JSON-RPC batch (a JSON array) to the server's stdin as a single frame
+func (t *stdioClientTransport) SendBatch(ctx context.Context, msgs []*types.BaseJSONRPCMessage) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf("transport is closed")
 	}
-	if err := t.writer.WriteByte('\n'); err != nil {
-		return fmt.Errorf("failed to write newline: %w", err)
+	if t.restarting {
+		return &ErrServerRestarted{Err: t.restartErr}
 	}
 
-	// Flush to ensure message is sent immediately
-	if err := t.writer.Flush(); err != nil {
-		return fmt.Errorf("failed to flush stdin: %w", err)
+	data, err := json.Marshal(msgs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
 	}
 
-	return nil
+	return writeFrame(t.writer, data, t.framing)
 }
 
 // Close shuts down the transport and terminates the server process
@@ -255,3 +411,12 @@ func (t *stdioClientTransport) SetCloseHandler(handler func()) {
 	defer t.mu.Unlock()
 	t.closeHandler = handler
 }
+
+// SetRestartHandler sets the callback invoked after WithSupervisor respawns
+// the child process, receiving the 1-based restart attempt and the error
+// that triggered it
+func (t *stdioClientTransport) SetRestartHandler(handler func(attempt int, err error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.restartHandler = handler
+}
@@ -0,0 +1,131 @@
+package stdio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Framing selects how JSON-RPC messages are delimited on the wire between a
+// stdio client and server.
+type Framing int
+
+const (
+	// FramingNDJSON frames each message as a single line of JSON terminated
+	// by '\n'. This is the default, kept for backward compatibility with
+	// existing stdio servers and clients.
+	FramingNDJSON Framing = iota
+	// FramingContentLength frames each message LSP-style: a header block of
+	// "Key: value\r\n" lines terminated by a blank line, carrying a required
+	// Content-Length, followed by exactly that many bytes of JSON. It tolerates
+	// pretty-printed JSON and embedded newlines, which NDJSON cannot.
+	FramingContentLength
+)
+
+// frameReader decodes successive JSON-RPC frames (a single object or a
+// batch array) from a *bufio.Reader. Plugging a new wire format in - e.g. a
+// length-prefixed varint framing - means implementing this interface and
+// adding a case to newFrameReader; readLoop itself never changes.
+type frameReader interface {
+	readFrame(r *bufio.Reader) ([]byte, error)
+}
+
+// ndjsonFrameReader implements frameReader for FramingNDJSON: one JSON
+// document per line, terminated by '\n'.
+type ndjsonFrameReader struct{}
+
+func (ndjsonFrameReader) readFrame(r *bufio.Reader) ([]byte, error) {
+	return r.ReadBytes('\n')
+}
+
+// contentLengthFrameReader implements frameReader for FramingContentLength.
+type contentLengthFrameReader struct{}
+
+func (contentLengthFrameReader) readFrame(r *bufio.Reader) ([]byte, error) {
+	return readContentLengthFrame(r)
+}
+
+// newFrameReader returns the frameReader for framing
+func newFrameReader(framing Framing) frameReader {
+	if framing == FramingContentLength {
+		return contentLengthFrameReader{}
+	}
+	return ndjsonFrameReader{}
+}
+
+// writeFrame writes data as one JSON-RPC message to w according to framing
+// and flushes it.
+func writeFrame(w *bufio.Writer, data []byte, framing Framing) error {
+	if framing == FramingContentLength {
+		return writeContentLengthFrame(w, data)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.WriteByte('\n'); err != nil {
+		return fmt.Errorf("failed to write newline: %w", err)
+	}
+	return w.Flush()
+}
+
+// readContentLengthFrame reads one "Content-Length: <N>\r\n\r\n<N bytes>"
+// frame from r. Header field names are matched case-insensitively and
+// unrecognized headers (e.g. Content-Type) are skipped. Blank lines and
+// non-header noise preceding the Content-Length line - such as stray
+// newlines or stderr output bleeding into the same stream - are scanned
+// past rather than treated as a malformed frame.
+func readContentLengthFrame(r *bufio.Reader) ([]byte, error) {
+	contentLength := -1
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if contentLength >= 0 {
+				break
+			}
+			// Blank line before any recognized header: keep scanning.
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			// Not a header line; ignore and keep scanning for one.
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length header %q: %w", value, err)
+			}
+			contentLength = n
+		}
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("failed to read message body: %w", err)
+	}
+	return body, nil
+}
+
+// writeContentLengthFrame writes data to w as a single LSP-style frame -
+// header and body combined into one Write call - and flushes it.
+func writeContentLengthFrame(w *bufio.Writer, data []byte) error {
+	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(data))
+	frame := make([]byte, 0, len(header)+len(data))
+	frame = append(frame, header...)
+	frame = append(frame, data...)
+
+	if _, err := w.Write(frame); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return w.Flush()
+}
@@ -10,4 +10,9 @@ type ServerTransport interface {
 // ClientTransport is a stdio transport for MCP clients
 type ClientTransport interface {
 	transport.ClientTransport
+	// SetRestartHandler sets the callback invoked after WithSupervisor
+	// respawns the child process, receiving the 1-based restart attempt and
+	// the error that triggered it. Higher layers should use this to re-run
+	// initialize and restore any server-side state.
+	SetRestartHandler(handler func(attempt int, err error))
 }
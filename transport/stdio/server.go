@@ -19,23 +19,44 @@ type stdioServerTransport struct {
 	messageHandler types.MessageHandler
 	errorHandler   types.ErrorHandler
 	closeHandler   types.CloseHandler
+	framing        Framing
+	frameReader    frameReader
 	mu             sync.RWMutex
 	ctx            context.Context
 	cancel         context.CancelFunc
 	closed         bool
 }
 
+// ServerTransportOption configures the server transport
+type ServerTransportOption func(*stdioServerTransport)
+
+// WithServerFraming selects the wire framing used to read and write
+// JSON-RPC messages. It defaults to FramingNDJSON; clients must be
+// configured with the same framing via stdio.WithFraming.
+func WithServerFraming(framing Framing) ServerTransportOption {
+	return func(t *stdioServerTransport) {
+		t.framing = framing
+	}
+}
+
 // NewServerTransport creates a new stdio server transport
 // Reads from stdin, writes to stdout
-func NewServerTransport() ServerTransport {
+func NewServerTransport(opts ...ServerTransportOption) ServerTransport {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &stdioServerTransport{
+	t := &stdioServerTransport{
 		reader: bufio.NewReader(os.Stdin),
 		writer: bufio.NewWriter(os.Stdout),
 		ctx:    ctx,
 		cancel: cancel,
 		closed: false,
 	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.frameReader = newFrameReader(t.framing)
+
+	return t
 }
 
 // Start begins reading messages from stdin
@@ -60,8 +81,8 @@ func (t *stdioServerTransport) readLoop() {
 		case <-t.ctx.Done():
 			return
 		default:
-			// Read line from stdin
-			line, err := t.reader.ReadBytes('\n')
+			// Read the next frame from stdin
+			line, err := t.frameReader.readFrame(t.reader)
 			if err != nil {
 				if err == io.EOF {
 					// Stdin closed, shut down gracefully
@@ -77,23 +98,38 @@ func (t *stdioServerTransport) readLoop() {
 				continue
 			}
 
+			t.mu.RLock()
+			messageHandler := t.messageHandler
+			errorHandler := t.errorHandler
+			t.mu.RUnlock()
+
+			// A JSON-RPC batch arrives as a top-level array; dispatch each
+			// element through the handler individually.
+			if isJSONArray(line) {
+				var batch []*types.BaseJSONRPCMessage
+				if err := json.Unmarshal(line, &batch); err != nil {
+					if errorHandler != nil {
+						errorHandler(fmt.Errorf("failed to parse JSON batch: %w", err))
+					}
+					continue
+				}
+				if messageHandler != nil {
+					for _, msg := range batch {
+						messageHandler(t.ctx, msg)
+					}
+				}
+				continue
+			}
+
 			// Parse JSON-RPC message
 			var msg types.BaseJSONRPCMessage
 			if err := json.Unmarshal(line, &msg); err != nil {
-				t.mu.RLock()
-				errorHandler := t.errorHandler
-				t.mu.RUnlock()
 				if errorHandler != nil {
 					errorHandler(fmt.Errorf("failed to parse JSON: %w", err))
 				}
 				continue
 			}
 
-			// Deliver message to handler
-			t.mu.RLock()
-			messageHandler := t.messageHandler
-			t.mu.RUnlock()
-
 			if messageHandler != nil {
 				messageHandler(t.ctx, &msg)
 			}
@@ -116,20 +152,44 @@ func (t *stdioServerTransport) Send(ctx context.Context, msg *types.BaseJSONRPCM
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	// Write to stdout with newline
-	if _, err := t.writer.Write(data); err != nil {
-		return fmt.Errorf("failed to write to stdout: %w", err)
+	if err := writeFrame(t.writer, data, t.framing); err != nil {
+		return err
 	}
-	if err := t.writer.WriteByte('\n'); err != nil {
-		return fmt.Errorf("failed to write newline: %w", err)
+
+	return nil
+}
+
+// SendBatch writes a JSON-RPC batch (a JSON array) to stdout as a single frame
+func (t *stdioServerTransport) SendBatch(ctx context.Context, msgs []*types.BaseJSONRPCMessage) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf("transport is closed")
 	}
 
-	// Flush to ensure message is sent immediately
-	if err := t.writer.Flush(); err != nil {
-		return fmt.Errorf("failed to flush stdout: %w", err)
+	data, err := json.Marshal(msgs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
 	}
 
-	return nil
+	return writeFrame(t.writer, data, t.framing)
+}
+
+// isJSONArray reports whether line's first non-whitespace byte opens a
+// JSON array, i.e. it carries a JSON-RPC batch rather than a single message.
+func isJSONArray(line []byte) bool {
+	for _, b := range line {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
 }
 
 // Close shuts down the transport
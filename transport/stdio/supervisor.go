@@ -0,0 +1,57 @@
+package stdio
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RestartPolicy configures how WithSupervisor respawns a crashed child
+// process: exponential backoff between InitialDelay and MaxDelay, with full
+// jitter applied unless Jitter is false, up to MaxRestarts attempts.
+type RestartPolicy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	// MaxRestarts caps the number of respawn attempts after the first
+	// unexpected exit. Zero means unlimited.
+	MaxRestarts int
+	// Jitter applies a uniform random delay between 0 and the computed
+	// backoff. Disabling it is mostly useful for deterministic tests.
+	Jitter bool
+}
+
+// ErrServerRestarted is returned by Send and SendBatch for a message that
+// couldn't be delivered because the supervised subprocess had exited and was
+// being respawned.
+type ErrServerRestarted struct {
+	// Err is the error - a process exit or pipe failure - that triggered
+	// the restart, if any.
+	Err error
+}
+
+func (e *ErrServerRestarted) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("stdio: server process restarted: %v", e.Err)
+	}
+	return "stdio: server process restarted"
+}
+
+func (e *ErrServerRestarted) Unwrap() error {
+	return e.Err
+}
+
+// restartBackoff returns min(MaxDelay, InitialDelay*2^attempt), with full
+// jitter applied per policy.Jitter.
+func restartBackoff(policy RestartPolicy, attempt int) time.Duration {
+	delay := policy.InitialDelay << attempt
+	if policy.MaxDelay > 0 && (delay > policy.MaxDelay || delay < 0) {
+		delay = policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	if !policy.Jitter {
+		return delay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
@@ -1,12 +1,14 @@
 package streamable
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,7 +16,18 @@ import (
 	"github.com/DR1N0/mcp-go/types"
 )
 
-// httpClientTransport implements streamable HTTP transport for MCP clients
+// httpClientTransport implements streamable HTTP transport for MCP clients.
+//
+// Send and SendBatch register the request's id(s) in pending before writing
+// the HTTP request, then hand the response body off to a per-request
+// dispatch goroutine and block on their own channel. The dispatch goroutine
+// routes every message it decodes - whether the body is a single JSON
+// document or a text/event-stream - to its matching pending channel, or to
+// messageHandler when the id is unrecognized (a notification, or a
+// server-initiated request arriving mid-response, e.g. sampling/createMessage).
+// This lets the caller have multiple requests in flight at once and receive
+// server callbacks between them, rather than blocking the whole transport on
+// one round trip at a time.
 type httpClientTransport struct {
 	url            string
 	client         *http.Client
@@ -24,11 +37,24 @@ type httpClientTransport struct {
 	mu             sync.RWMutex
 	timeout        time.Duration
 	closed         bool
+	listen         bool
+	sessionID      string
+	ctx            context.Context
+	cancel         context.CancelFunc
+
+	pendingMu sync.Mutex
+	pending   map[interface{}]chan *types.BaseJSONRPCMessage
 }
 
+// defaultListenRetryDelay is how long the background GET listener opened by
+// WithListen waits before reconnecting after the stream ends or fails to
+// connect.
+const defaultListenRetryDelay = 3 * time.Second
+
 // NewClientTransport creates a new streamable HTTP client transport
 // url is the full endpoint URL (e.g., "http://localhost:8000/mcp")
 func NewClientTransport(url string, opts ...ClientTransportOption) ClientTransport {
+	ctx, cancel := context.WithCancel(context.Background())
 	t := &httpClientTransport{
 		url: url,
 		client: &http.Client{
@@ -36,6 +62,9 @@ func NewClientTransport(url string, opts ...ClientTransportOption) ClientTranspo
 		},
 		timeout: 30 * time.Second,
 		closed:  false,
+		ctx:     ctx,
+		cancel:  cancel,
+		pending: make(map[interface{}]chan *types.BaseJSONRPCMessage),
 	}
 
 	// Apply options
@@ -66,69 +95,418 @@ func WithTimeout(timeout time.Duration) ClientTransportOption {
 	}
 }
 
-// Start initializes the client transport (no-op for HTTP client)
+// WithListen opens a background GET stream to the server so it can
+// originate its own requests (e.g. sampling/createMessage, roots/list) and
+// notifications outside of a client POST/response exchange. Without it,
+// this client transport can only receive messages the server sends as the
+// direct response to one of its own requests.
+func WithListen(enabled bool) ClientTransportOption {
+	return func(t *httpClientTransport) {
+		t.listen = enabled
+	}
+}
+
+// Start initializes the client transport and, if WithListen was set, opens
+// the background GET listening stream
 func (t *httpClientTransport) Start(ctx context.Context) error {
 	t.mu.Lock()
-	defer t.mu.Unlock()
-
 	if t.closed {
+		t.mu.Unlock()
 		return fmt.Errorf("transport is closed")
 	}
+	listen := t.listen
+	t.mu.Unlock()
+
+	if listen {
+		go t.listenLoop()
+	}
 
 	return nil
 }
 
-// Send sends a message to the server and waits for the response
+// listenLoop keeps the background GET listening stream open, reconnecting
+// with defaultListenRetryDelay between attempts until the transport closes
+func (t *httpClientTransport) listenLoop() {
+	for t.ctx.Err() == nil {
+		if err := t.listenOnce(); err != nil {
+			t.reportError(fmt.Errorf("listen stream: %w", err))
+		}
+
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-time.After(defaultListenRetryDelay):
+		}
+	}
+}
+
+// listenOnce opens one GET connection to the listening stream and routes
+// every message it carries until the stream ends or t closes
+func (t *httpClientTransport) listenOnce() error {
+	req, err := http.NewRequestWithContext(t.ctx, http.MethodGet, t.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	t.setSessionHeader(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("server returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	t.dispatchEventStream(t.ctx, resp.Body)
+	return nil
+}
+
+// recordSessionID remembers the Mcp-Session-Id the server assigned on
+// initialize, so the background GET listener and later requests can bind to
+// the same session.
+func (t *httpClientTransport) recordSessionID(resp *http.Response) {
+	id := resp.Header.Get("Mcp-Session-Id")
+	if id == "" {
+		return
+	}
+	t.mu.Lock()
+	t.sessionID = id
+	t.mu.Unlock()
+}
+
+// register creates and returns a channel for id's correlated response,
+// along with a cleanup func the caller must defer. id == nil (a
+// notification) registers nothing and returns a nil channel.
+func (t *httpClientTransport) register(id interface{}) (chan *types.BaseJSONRPCMessage, func()) {
+	if id == nil {
+		return nil, func() {}
+	}
+
+	ch := make(chan *types.BaseJSONRPCMessage, 1)
+	t.pendingMu.Lock()
+	t.pending[id] = ch
+	t.pendingMu.Unlock()
+
+	return ch, func() {
+		t.pendingMu.Lock()
+		delete(t.pending, id)
+		t.pendingMu.Unlock()
+	}
+}
+
+// routeMessage delivers msg to the pending call it correlates with, if any;
+// otherwise it's a notification or a server-initiated request, so it goes
+// to the general message handler instead.
+func (t *httpClientTransport) routeMessage(ctx context.Context, msg *types.BaseJSONRPCMessage) {
+	if msg.ID != nil {
+		t.pendingMu.Lock()
+		ch, ok := t.pending[msg.ID]
+		t.pendingMu.Unlock()
+		if ok {
+			select {
+			case ch <- msg:
+			default:
+			}
+			return
+		}
+	}
+
+	t.mu.RLock()
+	handler := t.messageHandler
+	t.mu.RUnlock()
+	if handler != nil {
+		handler(ctx, msg)
+	}
+}
+
+// routeRaw decodes raw as either a single JSON-RPC message or a batch, and
+// routes each one.
+func (t *httpClientTransport) routeRaw(ctx context.Context, raw []byte) {
+	if len(raw) == 0 {
+		return
+	}
+
+	if isJSONArray(raw) {
+		var msgs []*types.BaseJSONRPCMessage
+		if err := json.Unmarshal(raw, &msgs); err != nil {
+			t.reportError(fmt.Errorf("failed to decode batch: %w", err))
+			return
+		}
+		for _, msg := range msgs {
+			t.routeMessage(ctx, msg)
+		}
+		return
+	}
+
+	var msg types.BaseJSONRPCMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		t.reportError(fmt.Errorf("failed to decode message: %w", err))
+		return
+	}
+	t.routeMessage(ctx, &msg)
+}
+
+// dispatchResponse reads resp's body to completion and routes every message
+// found in it, then closes the body. application/json bodies carry exactly
+// one message (or batch); text/event-stream bodies may carry several,
+// delivered as the server produces them - including a server-initiated
+// request the client must answer before the server sends the final
+// correlated response.
+func (t *httpClientTransport) dispatchResponse(ctx context.Context, resp *http.Response) {
+	defer resp.Body.Close()
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.dispatchEventStream(ctx, resp.Body)
+		return
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.reportError(fmt.Errorf("failed to read response: %w", err))
+		return
+	}
+	t.routeRaw(ctx, body)
+}
+
+// dispatchEventStream reads "event: message\ndata: ...\n\n" frames off body
+// and routes each frame's data as it completes.
+func (t *httpClientTransport) dispatchEventStream(ctx context.Context, body io.Reader) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var dataLines []string
+	flush := func() {
+		if len(dataLines) == 0 {
+			return
+		}
+		data := strings.Join(dataLines, "\n")
+		dataLines = dataLines[:0]
+		t.routeRaw(ctx, []byte(data))
+	}
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		default:
+			// event:, id:, retry: - nothing else needs this client's attention
+		}
+	}
+	flush()
+}
+
+func (t *httpClientTransport) reportError(err error) {
+	t.mu.RLock()
+	errorHandler := t.errorHandler
+	t.mu.RUnlock()
+	if errorHandler != nil {
+		errorHandler(err)
+	}
+}
+
+// isResponse reports whether msg is a JSON-RPC response (to a
+// server-initiated request received over the GET listening stream) rather
+// than a request or notification this client is originating: it carries an
+// id but no method, since responses never do.
+func isResponse(msg *types.BaseJSONRPCMessage) bool {
+	return msg.ID != nil && msg.Method == ""
+}
+
+// Send sends a message to the server. If msg carries an id and is a
+// request this client originated, Send blocks until the correlated
+// response is routed back by dispatchResponse, or ctx is cancelled. A
+// notification (nil id) or a response to a server-initiated request
+// returns as soon as the request is written, and its response body (if
+// any) is still drained and dispatched in the background.
 func (t *httpClientTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMessage) error {
 	t.mu.RLock()
 	if t.closed {
 		t.mu.RUnlock()
 		return fmt.Errorf("transport is closed")
 	}
-	messageHandler := t.messageHandler
 	t.mu.RUnlock()
 
-	// Marshal the message
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
-
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.setSessionHeader(req)
+
+	waitForResponse := msg.ID != nil && !isResponse(msg)
+
+	var respChan chan *types.BaseJSONRPCMessage
+	cleanup := func() {}
+	if waitForResponse {
+		respChan, cleanup = t.register(msg.ID)
+	}
+	defer cleanup()
 
-	// Send request
 	resp, err := t.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
-	defer resp.Body.Close()
+	t.recordSessionID(resp)
 
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusAccepted {
 		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		return fmt.Errorf("server returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Read response
-	var response types.BaseJSONRPCMessage
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if respChan == nil {
+		go t.dispatchResponse(ctx, resp)
+		return nil
 	}
 
-	// Deliver response to message handler if set
-	if messageHandler != nil {
-		messageHandler(ctx, &response)
+	go t.dispatchResponse(ctx, resp)
+
+	select {
+	case response := <-respChan:
+		t.mu.RLock()
+		handler := t.messageHandler
+		t.mu.RUnlock()
+		if handler != nil {
+			handler(ctx, response)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// setSessionHeader attaches the Mcp-Session-Id recorded from a prior
+// response, if any, so the server can bind req to the caller's session
+func (t *httpClientTransport) setSessionHeader(req *http.Request) {
+	t.mu.RLock()
+	sessionID := t.sessionID
+	t.mu.RUnlock()
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
 	}
+}
 
+// SendBatch sends a JSON-RPC batch to the server and dispatches each
+// response in the returned array to the message handler
+func (t *httpClientTransport) SendBatch(ctx context.Context, msgs []*types.BaseJSONRPCMessage) error {
+	responses, err := t.sendBatch(ctx, msgs)
+	if err != nil {
+		return err
+	}
+
+	t.mu.RLock()
+	handler := t.messageHandler
+	t.mu.RUnlock()
+	if handler != nil {
+		for _, response := range responses {
+			handler(ctx, response)
+		}
+	}
 	return nil
 }
 
+// SendBatchSync sends a JSON-RPC batch and returns its correlated
+// responses directly, in the same order as the requests in msgs that
+// carry an id, instead of delivering them asynchronously to the
+// registered MessageHandler. Notifications in msgs contribute no
+// response, so the result may be shorter than msgs.
+func (t *httpClientTransport) SendBatchSync(ctx context.Context, msgs []*types.BaseJSONRPCMessage) ([]*types.BaseJSONRPCMessage, error) {
+	return t.sendBatch(ctx, msgs)
+}
+
+// sendBatch does the actual work shared by SendBatch and SendBatchSync: it
+// registers a pending slot per request id, preserving order-agnostic id
+// correlation the same way Send does for a single message, POSTs the
+// batch, and waits for every request's slot to be filled.
+func (t *httpClientTransport) sendBatch(ctx context.Context, msgs []*types.BaseJSONRPCMessage) ([]*types.BaseJSONRPCMessage, error) {
+	t.mu.RLock()
+	if t.closed {
+		t.mu.RUnlock()
+		return nil, fmt.Errorf("transport is closed")
+	}
+	t.mu.RUnlock()
+
+	data, err := json.Marshal(msgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.setSessionHeader(req)
+
+	channels := make([]chan *types.BaseJSONRPCMessage, 0, len(msgs))
+	cleanups := make([]func(), 0, len(msgs))
+	for _, msg := range msgs {
+		if isResponse(msg) {
+			continue
+		}
+		ch, cleanup := t.register(msg.ID)
+		if ch != nil {
+			channels = append(channels, ch)
+			cleanups = append(cleanups, cleanup)
+		}
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}()
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	t.recordSessionID(resp)
+
+	// An all-notifications (or all-responses) batch gets no response body
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusAccepted {
+		resp.Body.Close()
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("server returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	go t.dispatchResponse(ctx, resp)
+
+	responses := make([]*types.BaseJSONRPCMessage, 0, len(channels))
+	for _, ch := range channels {
+		select {
+		case resp := <-ch:
+			responses = append(responses, resp)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return responses, nil
+}
+
 // Close shuts down the client transport
 func (t *httpClientTransport) Close() error {
 	t.mu.Lock()
@@ -139,6 +517,7 @@ func (t *httpClientTransport) Close() error {
 	}
 
 	t.closed = true
+	t.cancel()
 
 	if t.closeHandler != nil {
 		t.closeHandler()
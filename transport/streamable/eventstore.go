@@ -0,0 +1,94 @@
+package streamable
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// defaultEventBufferSize bounds how many events the in-memory EventStore
+// retains per stream; older events fall off once a stream exceeds it.
+const defaultEventBufferSize = 256
+
+// EventStore buffers recently sent SSE events per stream so a client that
+// reconnects with a Last-Event-ID header can replay whatever it missed.
+// Implementations must be safe for concurrent use. The default is an
+// in-process ring buffer; plug in a Redis-backed EventStore (via
+// WithEventStore) to make replay survive restarts or work across
+// horizontally scaled replicas.
+type EventStore interface {
+	// Append records data as the next event on streamID and returns the
+	// event ID assigned to it.
+	Append(streamID string, data []byte) (eventID string, err error)
+	// Replay calls fn, in order, for every event recorded on streamID after
+	// lastEventID. An empty lastEventID replays the whole retained buffer.
+	Replay(streamID, lastEventID string, fn func(eventID string, data []byte) error) error
+}
+
+// memoryEvent is a single buffered event within a memoryEventStore stream.
+type memoryEvent struct {
+	seq  uint64
+	data []byte
+}
+
+// memoryEventStore is the default EventStore: an in-process, fixed-size
+// ring buffer per stream. It does not survive process restarts and does
+// not share state across replicas.
+type memoryEventStore struct {
+	mu      sync.Mutex
+	bufSize int
+	seqs    map[string]uint64
+	events  map[string][]memoryEvent
+}
+
+// NewMemoryEventStore creates the default in-process EventStore.
+func NewMemoryEventStore() EventStore {
+	return &memoryEventStore{
+		bufSize: defaultEventBufferSize,
+		seqs:    make(map[string]uint64),
+		events:  make(map[string][]memoryEvent),
+	}
+}
+
+// Append implements EventStore.
+func (s *memoryEventStore) Append(streamID string, data []byte) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seqs[streamID]++
+	seq := s.seqs[streamID]
+
+	events := append(s.events[streamID], memoryEvent{seq: seq, data: data})
+	if len(events) > s.bufSize {
+		events = events[len(events)-s.bufSize:]
+	}
+	s.events[streamID] = events
+
+	return strconv.FormatUint(seq, 10), nil
+}
+
+// Replay implements EventStore.
+func (s *memoryEventStore) Replay(streamID, lastEventID string, fn func(eventID string, data []byte) error) error {
+	var after uint64
+	if lastEventID != "" {
+		v, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Last-Event-ID %q: %w", lastEventID, err)
+		}
+		after = v
+	}
+
+	s.mu.Lock()
+	events := append([]memoryEvent(nil), s.events[streamID]...)
+	s.mu.Unlock()
+
+	for _, ev := range events {
+		if ev.seq <= after {
+			continue
+		}
+		if err := fn(strconv.FormatUint(ev.seq, 10), ev.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package streamable
 
 import (
+	"context"
 	"net/http"
+	"net/netip"
 
 	"github.com/DR1N0/mcp-go/transport"
 	"github.com/DR1N0/mcp-go/types"
@@ -19,9 +21,36 @@ type ServerTransport interface {
 	HTTPHandler
 	// WithMiddleware adds HTTP middleware to be chained before the MCP handler
 	WithMiddleware(middleware ...types.HTTPMiddleware) ServerTransport
+	// WithTrustedProxies sets the address ranges allowed to report a client
+	// IP via the configured forwarded header (see WithForwardedHeader)
+	WithTrustedProxies(proxies []netip.Prefix) ServerTransport
+	// WithForwardedHeader selects which forwarding header to trust:
+	// "X-Forwarded-For" (default), "X-Real-IP", or "Forwarded"
+	WithForwardedHeader(header string) ServerTransport
+	// WithTrustHops caps how many proxy hops are skipped when walking the
+	// forwarded header; zero (the default) walks until an untrusted hop
+	WithTrustHops(hops int) ServerTransport
+	// WithSessions enables Mcp-Session-Id issuance and validation: the
+	// transport assigns a session ID on the response to "initialize" and
+	// requires it on every subsequent request to this endpoint, including
+	// the GET listening stream and DELETE termination.
+	WithSessions() ServerTransport
+	// WithSessionStore plugs in the store used for Mcp-Session-Id-bound
+	// sessions. The default is an in-process map; pass a Redis-backed
+	// SessionStore to validate session IDs across horizontally scaled
+	// replicas.
+	WithSessionStore(store SessionStore) ServerTransport
+	// WithEventStore plugs in the buffer used for Last-Event-ID replay on
+	// the GET listening stream. The default is an in-process ring buffer;
+	// pass a Redis-backed EventStore to support horizontal scaling.
+	WithEventStore(store EventStore) ServerTransport
 }
 
 // ClientTransport is a streamable HTTP client transport
 type ClientTransport interface {
 	transport.ClientTransport
+	// SendBatchSync sends a JSON-RPC batch and returns its correlated
+	// responses directly, instead of delivering them asynchronously to
+	// the registered MessageHandler as SendBatch does.
+	SendBatchSync(ctx context.Context, msgs []*types.BaseJSONRPCMessage) ([]*types.BaseJSONRPCMessage, error)
 }
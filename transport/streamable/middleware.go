@@ -0,0 +1,145 @@
+package streamable
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DR1N0/mcp-go/types"
+)
+
+// TimeoutMiddleware bounds every request reaching next to timeout, via a
+// context.WithTimeout on the request's context. It's the transport's
+// innermost middleware, so it applies even when no user middleware is
+// registered with WithMiddleware.
+func TimeoutMiddleware(timeout time.Duration) types.HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// statusCapturingWriter records the status code passed to WriteHeader so
+// LoggingMiddleware can report it after the handler returns. http.ResponseWriter
+// defaults to 200 if WriteHeader is never called.
+type statusCapturingWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusCapturingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// LoggingMiddleware returns an HTTPMiddleware that logs each request's
+// method, path, remote address, status code, and duration to logger
+func LoggingMiddleware(logger *log.Logger) types.HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusCapturingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+			logger.Printf("method=%s path=%s remote=%s status=%d duration=%s",
+				r.Method, r.URL.Path, r.RemoteAddr, sw.status, time.Since(start))
+		})
+	}
+}
+
+// RecoveryMiddleware returns an HTTPMiddleware that recovers from panics in
+// next, responding with a JSON-RPC -32603 internal error instead of letting
+// net/http's default recoverer tear down the connection and return a bare
+// 500 with no body the client can parse.
+func RecoveryMiddleware() types.HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.Printf("recovered panic in %s %s: %v", r.Method, r.URL.Path, rec)
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(&types.BaseJSONRPCMessage{
+						JSONRPC: "2.0",
+						Error: &types.RPCError{
+							Code:    -32603,
+							Message: "Internal error",
+						},
+					})
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CORSMiddleware returns an HTTPMiddleware that adds CORS headers for the
+// given allowed origins and methods, answering preflight OPTIONS requests
+// directly. A single "*" in origins allows any origin.
+func CORSMiddleware(origins []string, methods []string) types.HTTPMiddleware {
+	allowAny := len(origins) == 1 && origins[0] == "*"
+	allowedMethods := strings.Join(methods, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			switch {
+			case allowAny:
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			case origin != "" && originAllowed(origin, origins):
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Vary", "Origin")
+			}
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Mcp-Session-Id, Last-Event-ID")
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// originAllowed reports whether origin appears in allowed
+func originAllowed(origin string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// BearerAuthMiddleware returns an HTTPMiddleware that requires an
+// "Authorization: Bearer <token>" header, rejecting the request with 401
+// before the JSON-RPC body is ever read if it's missing or validate rejects
+// the token. On success, the claims validate returns are attached to the
+// request context via types.WithAuthClaims for handlers to consult.
+func BearerAuthMiddleware(validate func(token string) (interface{}, error)) types.HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const prefix = "Bearer "
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, prefix) {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := validate(strings.TrimPrefix(header, prefix))
+			if err != nil {
+				http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := types.WithAuthClaims(r.Context(), claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
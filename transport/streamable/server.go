@@ -2,13 +2,20 @@ package streamable
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/netip"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/DR1N0/mcp-go/transport"
 	"github.com/DR1N0/mcp-go/types"
 )
 
@@ -18,6 +25,13 @@ type pendingRequest struct {
 	ctx          context.Context
 }
 
+// sseEvent is a single message queued for delivery over a GET listening
+// stream, tagged with the ID it was assigned in the EventStore.
+type sseEvent struct {
+	id   string
+	data []byte
+}
+
 // httpServerTransport implements streamable HTTP transport for MCP servers
 type httpServerTransport struct {
 	endpoint        string
@@ -26,23 +40,96 @@ type httpServerTransport struct {
 	messageHandler  types.MessageHandler
 	errorHandler    types.ErrorHandler
 	closeHandler    types.CloseHandler
-	pendingRequests map[interface{}]*pendingRequest
+	pendingRequests *pendingTable
 	mu              sync.RWMutex
 	timeout         time.Duration
+	clientIPConfig  transport.ClientIPConfig
+	sessionsEnabled bool
+	sessionStore    SessionStore
+	ctx             context.Context
+	cancel          context.CancelFunc
+	listeners       map[string]chan sseEvent
+	eventStore      EventStore
+	middleware      []types.HTTPMiddleware
 }
 
 // NewServerTransport creates a new streamable HTTP server transport
 // endpoint is the HTTP path (e.g., "/mcp")
 // addr is the address to listen on (e.g., ":8000")
 func NewServerTransport(endpoint, addr string) ServerTransport {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &httpServerTransport{
 		endpoint:        endpoint,
 		addr:            addr,
-		pendingRequests: make(map[interface{}]*pendingRequest),
+		pendingRequests: newPendingTable(),
 		timeout:         30 * time.Second, // Default 30 second timeout
+		sessionStore:    NewMemorySessionStore(ctx),
+		ctx:             ctx,
+		cancel:          cancel,
+		listeners:       make(map[string]chan sseEvent),
+		eventStore:      NewMemoryEventStore(),
 	}
 }
 
+// WithSessions enables Mcp-Session-Id issuance and validation
+func (t *httpServerTransport) WithSessions() ServerTransport {
+	t.sessionsEnabled = true
+	return t
+}
+
+// WithSessionStore plugs in the store used for Mcp-Session-Id-bound
+// sessions. The default is an in-process map; pass a Redis-backed
+// SessionStore to validate session IDs across horizontally scaled
+// replicas.
+func (t *httpServerTransport) WithSessionStore(store SessionStore) ServerTransport {
+	t.sessionStore = store
+	return t
+}
+
+// WithEventStore plugs in the buffer used for Last-Event-ID replay on the
+// GET listening stream
+func (t *httpServerTransport) WithEventStore(store EventStore) ServerTransport {
+	t.eventStore = store
+	return t
+}
+
+// WithMiddleware adds HTTP middleware to be chained before the MCP handler.
+// Middleware is chained in reverse order (last added = outermost wrapper)
+// and runs before the request body is read, so e.g. an auth middleware can
+// reject a request without ever touching the JSON-RPC payload.
+func (t *httpServerTransport) WithMiddleware(middleware ...types.HTTPMiddleware) ServerTransport {
+	t.middleware = append(t.middleware, middleware...)
+	return t
+}
+
+// generateSessionID creates a new random session ID
+func generateSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithTrustedProxies sets the address ranges allowed to report a client IP
+// via the configured forwarded header
+func (t *httpServerTransport) WithTrustedProxies(proxies []netip.Prefix) ServerTransport {
+	t.clientIPConfig.TrustedProxies = proxies
+	return t
+}
+
+// WithForwardedHeader selects which forwarding header to trust:
+// "X-Forwarded-For" (default), "X-Real-IP", or "Forwarded"
+func (t *httpServerTransport) WithForwardedHeader(header string) ServerTransport {
+	t.clientIPConfig.ForwardedHeader = header
+	return t
+}
+
+// WithTrustHops caps how many proxy hops are skipped when walking the
+// forwarded header; zero (the default) walks until an untrusted hop
+func (t *httpServerTransport) WithTrustHops(hops int) ServerTransport {
+	t.clientIPConfig.TrustHops = hops
+	return t
+}
+
 // Start initializes the HTTP server and begins listening
 func (t *httpServerTransport) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
@@ -54,9 +141,18 @@ func (t *httpServerTransport) Start(ctx context.Context) error {
 		w.Write([]byte("OK"))
 	})
 
+	// TimeoutMiddleware is always innermost, giving every request the
+	// transport's configured deadline even if no middleware is registered;
+	// user middleware is chained outside it in reverse order (last added =
+	// outermost), so it sees the request before TimeoutMiddleware applies.
+	var handler http.Handler = TimeoutMiddleware(t.timeout)(mux)
+	for i := len(t.middleware) - 1; i >= 0; i-- {
+		handler = t.middleware[i](handler)
+	}
+
 	t.server = &http.Server{
 		Addr:    t.addr,
-		Handler: mux,
+		Handler: handler,
 	}
 
 	log.Printf("Streamable HTTP server starting on http://localhost%s%s", t.addr, t.endpoint)
@@ -77,21 +173,92 @@ func (t *httpServerTransport) Start(ctx context.Context) error {
 	return nil
 }
 
-// ServeHTTP handles incoming HTTP requests
+// ServeHTTP dispatches the streamable HTTP methods: POST submits a message
+// (or batch) and waits for its response, GET opens the long-lived SSE
+// listening stream for server-initiated requests and notifications, and
+// DELETE explicitly terminates a session.
 func (t *httpServerTransport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Only accept POST requests
-	if r.Method != http.MethodPost {
+	switch r.Method {
+	case http.MethodPost:
+		t.serveMessage(w, r)
+	case http.MethodGet:
+		t.serveListen(w, r)
+	case http.MethodDelete:
+		t.serveTerminate(w, r)
+	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// sessionFor resolves the caller's Mcp-Session-Id header against the
+// session store. When sessions are disabled it always succeeds with a nil
+// session, so callers can treat the two modes uniformly.
+func (t *httpServerTransport) sessionFor(r *http.Request) (*Session, error) {
+	if !t.sessionsEnabled {
+		return nil, nil
+	}
+
+	id := r.Header.Get("Mcp-Session-Id")
+	if id == "" {
+		return nil, errMissingSessionID
+	}
+
+	session, ok := t.sessionStore.Get(id)
+	if !ok {
+		return nil, sessionLookupError(id)
+	}
+	return session, nil
+}
+
+// sessionErrorStatus maps a sessionFor failure to the HTTP status it
+// should be reported with: 404 for an unrecognized Mcp-Session-Id, 400 for
+// anything else (e.g. the header being missing entirely).
+func sessionErrorStatus(err error) int {
+	if errors.Is(err, errUnknownSession) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+// watchSession fires the transport's close handler and evicts session from
+// the store once its context ends, whether from an explicit DELETE
+// (serveTerminate) or the idle timeout.
+func (t *httpServerTransport) watchSession(session *Session) {
+	go func() {
+		<-session.ctx.Done()
+		t.sessionStore.Delete(session.ID())
+
+		t.mu.RLock()
+		closeHandler := t.closeHandler
+		t.mu.RUnlock()
+		if closeHandler != nil {
+			closeHandler()
+		}
+	}()
+}
+
+// serveMessage handles a POST submission: a single JSON-RPC message or a
+// batch. Notifications and responses on their own don't block; requests
+// (messages carrying an ID) block for their correlated response.
+func (t *httpServerTransport) serveMessage(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if isJSONArray(body) {
+		t.serveBatch(w, r, body)
 		return
 	}
 
 	// Decode the JSON-RPC request
 	var req types.BaseJSONRPCMessage
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
 		return
 	}
-	defer r.Body.Close()
 
 	log.Printf("Received request: method=%s, id=%v (type: %T)", req.Method, req.ID, req.ID)
 
@@ -105,7 +272,29 @@ func (t *httpServerTransport) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ctx := r.Context()
+	// "initialize" is the one method allowed before a session exists, since
+	// the client can't yet know the session ID it's about to be assigned.
+	var session *Session
+	if t.sessionsEnabled {
+		if req.Method == "initialize" {
+			session = t.sessionStore.Create()
+			w.Header().Set("Mcp-Session-Id", session.ID())
+			t.watchSession(session)
+		} else {
+			s, err := t.sessionFor(r)
+			if err != nil {
+				http.Error(w, err.Error(), sessionErrorStatus(err))
+				return
+			}
+			s.touch()
+			session = s
+		}
+	}
+
+	ctx := t.contextWithClientIP(r)
+	if session != nil {
+		ctx = withSession(ctx, session)
+	}
 
 	// Handle notifications (no ID) - don't wait for response
 	if req.ID == nil {
@@ -118,6 +307,28 @@ func (t *httpServerTransport) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// A message with an ID but no method is the client's response to a
+	// server-initiated request (e.g. sampling/createMessage) delivered
+	// earlier over the GET listening stream. It resolves the Protocol's own
+	// pending call internally rather than this transport's pendingRequests
+	// table, so there's nothing here to wait on - acknowledge and return.
+	if isResponse(&req) {
+		log.Printf("Handling response to server-initiated request: id=%v", req.ID)
+		go func() {
+			handler(ctx, &req)
+		}()
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	// Requests from a session are isolated in that session's own pending
+	// table so two concurrent clients reusing the same id can't collide;
+	// anonymous clients (sessions disabled) share the transport-wide one.
+	table := t.pendingRequests
+	if session != nil {
+		table = session.pendingRequests
+	}
+
 	// For requests (have ID), register pending request and wait for response
 	responseChan := make(chan *types.BaseJSONRPCMessage, 1)
 
@@ -126,17 +337,12 @@ func (t *httpServerTransport) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		ctx:          ctx,
 	}
 
-	// Register the pending request
-	t.mu.Lock()
-	t.pendingRequests[req.ID] = pending
-	log.Printf("Registered pending request with id=%v (type: %T), total pending: %d", req.ID, req.ID, len(t.pendingRequests))
-	t.mu.Unlock()
+	table.register(req.ID, pending)
+	log.Printf("Registered pending request with id=%v (type: %T)", req.ID, req.ID)
 
 	// Ensure cleanup
 	defer func() {
-		t.mu.Lock()
-		delete(t.pendingRequests, req.ID)
-		t.mu.Unlock()
+		table.delete(req.ID)
 		close(responseChan)
 	}()
 
@@ -145,8 +351,9 @@ func (t *httpServerTransport) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		handler(ctx, &req)
 	}()
 
-	// Wait for response with timeout
-	timeout := time.After(t.timeout)
+	// Wait for the response. ctx carries whatever deadline the default
+	// TimeoutMiddleware (or a caller-supplied replacement) attached to the
+	// request, so there's no separate hard-coded timer here.
 	select {
 	case response := <-responseChan:
 		w.Header().Set("Content-Type", "application/json")
@@ -154,15 +361,278 @@ func (t *httpServerTransport) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			log.Printf("Error encoding response: %v", err)
 			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		}
-	case <-timeout:
-		log.Printf("Request timeout for id=%v", req.ID)
-		http.Error(w, "Request timeout", http.StatusRequestTimeout)
 	case <-ctx.Done():
-		log.Printf("Request cancelled for id=%v", req.ID)
-		http.Error(w, "Request cancelled", http.StatusRequestTimeout)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			log.Printf("Request timeout for id=%v", req.ID)
+			http.Error(w, "Request timeout", http.StatusRequestTimeout)
+		} else {
+			log.Printf("Request cancelled for id=%v", req.ID)
+			http.Error(w, "Request cancelled", http.StatusRequestTimeout)
+		}
 	}
 }
 
+// serveBatch handles a JSON-RPC batch (a top-level JSON array) POSTed to the endpoint
+func (t *httpServerTransport) serveBatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	var batch []*types.BaseJSONRPCMessage
+	if err := json.Unmarshal(body, &batch); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid batch: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	t.mu.RLock()
+	handler := t.messageHandler
+	t.mu.RUnlock()
+
+	if handler == nil {
+		http.Error(w, "No message handler registered", http.StatusInternalServerError)
+		return
+	}
+
+	var session *Session
+	if t.sessionsEnabled {
+		s, err := t.sessionFor(r)
+		if err != nil {
+			http.Error(w, err.Error(), sessionErrorStatus(err))
+			return
+		}
+		s.touch()
+		session = s
+	}
+
+	ctx := t.contextWithClientIP(r)
+	if session != nil {
+		ctx = withSession(ctx, session)
+	}
+
+	table := t.pendingRequests
+	if session != nil {
+		table = session.pendingRequests
+	}
+
+	type pendingEntry struct {
+		id           interface{}
+		responseChan chan *types.BaseJSONRPCMessage
+	}
+	pendingEntries := make([]pendingEntry, 0, len(batch))
+
+	for _, msg := range batch {
+		if msg.ID == nil || isResponse(msg) {
+			// Notification, or a response to a server-initiated request:
+			// dispatch without waiting for a correlated response
+			go handler(ctx, msg)
+			continue
+		}
+
+		responseChan := make(chan *types.BaseJSONRPCMessage, 1)
+		pending := &pendingRequest{responseChan: responseChan, ctx: ctx}
+		table.register(msg.ID, pending)
+
+		pendingEntries = append(pendingEntries, pendingEntry{id: msg.ID, responseChan: responseChan})
+
+		go handler(ctx, msg)
+	}
+
+	defer func() {
+		for _, entry := range pendingEntries {
+			table.delete(entry.id)
+		}
+	}()
+
+	if len(pendingEntries) == 0 {
+		// An all-notifications batch gets no response body, per spec
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	responses := make([]*types.BaseJSONRPCMessage, 0, len(pendingEntries))
+	for _, entry := range pendingEntries {
+		select {
+		case response := <-entry.responseChan:
+			responses = append(responses, response)
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				http.Error(w, "Request timeout", http.StatusRequestTimeout)
+			} else {
+				http.Error(w, "Request cancelled", http.StatusRequestTimeout)
+			}
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(responses); err != nil {
+		log.Printf("Error encoding batch response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// contextWithClientIP resolves the real client address for r per the
+// transport's trusted-proxy configuration and attaches it to r's context
+func (t *httpServerTransport) contextWithClientIP(r *http.Request) context.Context {
+	addr, ok := transport.ResolveClientIP(r.RemoteAddr, r.Header, t.clientIPConfig)
+	if !ok {
+		return r.Context()
+	}
+	return types.WithClientIP(r.Context(), addr)
+}
+
+// serveListen opens the long-lived GET SSE stream a client uses to receive
+// server-initiated requests and notifications outside of a POST/response
+// exchange. A Last-Event-ID header replays buffered events before the
+// stream switches to live delivery.
+func (t *httpServerTransport) serveListen(w http.ResponseWriter, r *http.Request) {
+	session, err := t.sessionFor(r)
+	if err != nil {
+		http.Error(w, err.Error(), sessionErrorStatus(err))
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		http.Error(w, "GET requires Accept: text/event-stream", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	streamID := t.listenStreamIDFor(session)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		err := t.eventStore.Replay(streamID, lastEventID, func(id string, data []byte) error {
+			fmt.Fprintf(w, "id: %s\nevent: message\ndata: %s\n\n", id, data)
+			flusher.Flush()
+			return nil
+		})
+		if err != nil {
+			log.Printf("SSE replay failed for stream %s: %v", streamID, err)
+		}
+	}
+
+	ch := make(chan sseEvent, 16)
+	t.mu.Lock()
+	t.listeners[streamID] = ch
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.listeners, streamID)
+		t.mu.Unlock()
+	}()
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-ch:
+			fmt.Fprintf(w, "id: %s\nevent: message\ndata: %s\n\n", ev.id, ev.data)
+			flusher.Flush()
+		}
+	}
+}
+
+// serveTerminate handles an explicit DELETE session termination: cancelling
+// the session's context tears it down through the same path as the idle
+// timeout (see watchSession), evicting it from the store and firing the
+// transport's close handler for it.
+func (t *httpServerTransport) serveTerminate(w http.ResponseWriter, r *http.Request) {
+	if !t.sessionsEnabled {
+		http.Error(w, "sessions are not enabled", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := t.sessionFor(r)
+	if err != nil {
+		http.Error(w, err.Error(), sessionErrorStatus(err))
+		return
+	}
+
+	session.cancel()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listenStreamIDFor names the EventStore/listener stream a session's GET
+// connection buffers and delivers events on. Sessions are disabled in some
+// deployments, so a nil session shares a single anonymous stream.
+func (t *httpServerTransport) listenStreamIDFor(session *Session) string {
+	if session == nil {
+		return "listen"
+	}
+	return session.ID() + ":listen"
+}
+
+// listenStreamID resolves the listening stream for outbound messages tied
+// to ctx, mirroring the session stashed there when the originating request
+// came in.
+func (t *httpServerTransport) listenStreamID(ctx context.Context) string {
+	if session, ok := SessionFromContext(ctx); ok {
+		return session.ID() + ":listen"
+	}
+	return "listen"
+}
+
+// publish buffers data in the EventStore and, if a GET stream is currently
+// listening on streamID, delivers it live.
+func (t *httpServerTransport) publish(streamID string, data []byte) {
+	id, err := t.eventStore.Append(streamID, data)
+	if err != nil {
+		log.Printf("failed to buffer SSE event for stream %s: %v", streamID, err)
+	}
+
+	t.mu.RLock()
+	ch, ok := t.listeners[streamID]
+	t.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case ch <- sseEvent{id: id, data: data}:
+	default:
+		log.Printf("SSE listener buffer full for stream %s, dropping event", streamID)
+	}
+}
+
+// isJSONArray reports whether body's first non-whitespace byte opens a
+// JSON array, i.e. it carries a JSON-RPC batch rather than a single message.
+func isJSONArray(body []byte) bool {
+	for _, b := range body {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// SendBatch sends a batch of responses/messages as a single JSON array.
+// Since the streamable transport correlates by the HTTP request that is
+// already blocking in serveBatch, this only needs to hand messages to Send
+// individually; serveBatch does the array assembly on the way out.
+func (t *httpServerTransport) SendBatch(ctx context.Context, msgs []*types.BaseJSONRPCMessage) error {
+	for _, msg := range msgs {
+		if err := t.Send(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Send sends a message (response) back to the client
 func (t *httpServerTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMessage) error {
 	if msg == nil {
@@ -171,15 +641,26 @@ func (t *httpServerTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMe
 
 	log.Printf("Send called: id=%v (type: %T), method=%s", msg.ID, msg.ID, msg.Method)
 
-	// Look up the pending request by ID
-	t.mu.RLock()
-	pending, ok := t.pendingRequests[msg.ID]
-	log.Printf("Looking up pending request for id=%v (type: %T), found=%v, total pending=%d", msg.ID, msg.ID, ok, len(t.pendingRequests))
-	t.mu.RUnlock()
+	// Look up the pending request by ID, in the session's own table if ctx
+	// carries one, else the transport-wide table shared by anonymous
+	// clients.
+	table := t.pendingRequests
+	if session, ok := SessionFromContext(ctx); ok {
+		table = session.pendingRequests
+	}
+	pending, ok := table.lookup(msg.ID)
+	log.Printf("Looking up pending request for id=%v (type: %T), found=%v", msg.ID, msg.ID, ok)
 
 	if !ok {
-		// If there's no pending request, this might be a notification
-		log.Printf("No pending request found for id=%v (type: %T) - might be a notification", msg.ID, msg.ID)
+		// No POST is blocked waiting on this ID: this is a server-initiated
+		// request or notification, so deliver it over the caller's SSE
+		// listening stream instead, buffering it for Last-Event-ID replay.
+		log.Printf("No pending request found for id=%v (type: %T), publishing to listening stream", msg.ID, msg.ID)
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal message: %w", err)
+		}
+		t.publish(t.listenStreamID(ctx), data)
 		return nil
 	}
 
@@ -196,12 +677,18 @@ func (t *httpServerTransport) Send(ctx context.Context, msg *types.BaseJSONRPCMe
 	}
 }
 
-// Close shuts down the HTTP server
+// Close shuts down the HTTP server. Any sessions still open are torn down
+// through the same path as an idle timeout or explicit DELETE (see
+// watchSession), each firing the transport's close handler from its own
+// goroutine; Close also fires it once for the transport itself, without
+// waiting for those.
 func (t *httpServerTransport) Close() error {
 	t.mu.RLock()
 	closeHandler := t.closeHandler
 	t.mu.RUnlock()
 
+	t.cancel()
+
 	if t.server != nil {
 		if err := t.server.Shutdown(context.Background()); err != nil {
 			return fmt.Errorf("failed to shutdown server: %w", err)
@@ -0,0 +1,199 @@
+package streamable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sessionIdleTimeout is how long a session may go without a request
+// touching it before it's torn down and the transport's close handler
+// fires for it.
+const sessionIdleTimeout = 5 * time.Minute
+
+var (
+	errMissingSessionID = errors.New("missing Mcp-Session-Id header")
+	errUnknownSession   = errors.New("unknown session")
+)
+
+// pendingTable is a mutex-guarded map of in-flight requests keyed by
+// JSON-RPC id. httpServerTransport uses one globally when sessions are
+// disabled; each Session owns its own instead, so two concurrent clients
+// that happen to reuse ids can never collide.
+type pendingTable struct {
+	mu       sync.Mutex
+	requests map[interface{}]*pendingRequest
+}
+
+func newPendingTable() *pendingTable {
+	return &pendingTable{requests: make(map[interface{}]*pendingRequest)}
+}
+
+func (p *pendingTable) register(id interface{}, pending *pendingRequest) {
+	p.mu.Lock()
+	p.requests[id] = pending
+	p.mu.Unlock()
+}
+
+func (p *pendingTable) lookup(id interface{}) (*pendingRequest, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pending, ok := p.requests[id]
+	return pending, ok
+}
+
+func (p *pendingTable) delete(id interface{}) {
+	p.mu.Lock()
+	delete(p.requests, id)
+	p.mu.Unlock()
+}
+
+// Session is one client bound by an Mcp-Session-Id. It owns its own
+// pending-request table, so requests from two concurrent sessions that
+// happen to reuse the same JSON-RPC id never collide, plus a scratchpad
+// for per-connection state a tool handler wants to attach to this client
+// specifically, e.g. subscriptions or roots.
+type Session struct {
+	id string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	idle   *time.Timer
+
+	pendingRequests *pendingTable
+
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// newSession creates a Session whose lifetime is bound to parent and which
+// self-evicts after sessionIdleTimeout without a touch() call.
+func newSession(parent context.Context, id string) *Session {
+	ctx, cancel := context.WithCancel(parent)
+	s := &Session{
+		id:              id,
+		ctx:             ctx,
+		cancel:          cancel,
+		pendingRequests: newPendingTable(),
+	}
+	s.idle = time.AfterFunc(sessionIdleTimeout, cancel)
+	return s
+}
+
+// ID returns the session's Mcp-Session-Id.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// touch resets the idle timeout, keeping the session alive for another
+// sessionIdleTimeout from now.
+func (s *Session) touch() {
+	s.idle.Reset(sessionIdleTimeout)
+}
+
+// Get returns the value stored under key, or nil if none was set.
+func (s *Session) Get(key string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key]
+}
+
+// Set stores value under key, for a tool handler to attach state - a
+// subscription, negotiated roots, whatever - to this specific session.
+func (s *Session) Set(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values == nil {
+		s.values = make(map[string]interface{})
+	}
+	s.values[key] = value
+}
+
+// sessionContextKey is the context key for the *Session carried through
+// message dispatch.
+type sessionContextKey struct{}
+
+// withSession returns a copy of ctx carrying session.
+func withSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+// SessionFromContext returns the Session bound to ctx, if sessions are
+// enabled (see WithSessions) and ctx was produced by a request on one.
+// Tool handlers use this to target server-initiated requests and
+// notifications, or attach subscriptions and cancellation, to the client
+// that originated the request being handled.
+func SessionFromContext(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(sessionContextKey{}).(*Session)
+	return session, ok
+}
+
+// SessionStore manages Session records for WithSessions. Implementations
+// must be safe for concurrent use. The default is an in-process map; a
+// Redis-backed SessionStore lets horizontally scaled replicas agree on
+// which session IDs are currently valid. A Session's pending-request table
+// stays process-local regardless - a blocked long-poll request can only be
+// served by the replica holding it, so SessionStore only arbitrates
+// existence, not in-flight state.
+type SessionStore interface {
+	// Create registers and returns a new Session with a fresh ID.
+	Create() *Session
+	// Get returns the Session for id, or false if unknown.
+	Get(id string) (*Session, bool)
+	// Delete removes the Session for id.
+	Delete(id string)
+}
+
+// memorySessionStore is the default SessionStore: an in-process map. It
+// does not survive process restarts and does not share state across
+// replicas.
+type memorySessionStore struct {
+	parent context.Context
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewMemorySessionStore creates the default in-process SessionStore. Every
+// Session it creates is bound to parent, so cancelling parent (e.g. on
+// transport Close) tears all of them down.
+func NewMemorySessionStore(parent context.Context) SessionStore {
+	return &memorySessionStore{
+		parent:   parent,
+		sessions: make(map[string]*Session),
+	}
+}
+
+// Create implements SessionStore.
+func (s *memorySessionStore) Create() *Session {
+	session := newSession(s.parent, generateSessionID())
+
+	s.mu.Lock()
+	s.sessions[session.id] = session
+	s.mu.Unlock()
+
+	return session
+}
+
+// Get implements SessionStore.
+func (s *memorySessionStore) Get(id string) (*Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	return session, ok
+}
+
+// Delete implements SessionStore.
+func (s *memorySessionStore) Delete(id string) {
+	s.mu.Lock()
+	delete(s.sessions, id)
+	s.mu.Unlock()
+}
+
+// sessionLookupError formats a sessionFor failure consistently; callers
+// map errUnknownSession to 404 and everything else to 400.
+func sessionLookupError(id string) error {
+	return fmt.Errorf("%w: %q", errUnknownSession, id)
+}
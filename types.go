@@ -2,6 +2,7 @@ package mcpgo
 
 // Re-export types from types package for convenience
 import (
+	"github.com/DR1N0/mcp-go/protocol"
 	"github.com/DR1N0/mcp-go/types"
 )
 
@@ -23,6 +24,9 @@ type ToolsCapability = types.ToolsCapability
 type PromptsCapability = types.PromptsCapability
 type ResourcesCapability = types.ResourcesCapability
 type LoggingCapability = types.LoggingCapability
+type CompletionsCapability = types.CompletionsCapability
+type ClientCapabilities = types.ClientCapabilities
+type SamplingCapability = types.SamplingCapability
 type ToolsResponse = types.ToolsResponse
 type Tool = types.Tool
 type ToolResponse = types.ToolResponse
@@ -37,16 +41,35 @@ type ListResourcesResponse = types.ListResourcesResponse
 type Resource = types.Resource
 type ResourceResponse = types.ResourceResponse
 type ResourceContent = types.ResourceContent
+type SamplingMessage = types.SamplingMessage
+type ModelHint = types.ModelHint
+type ModelPreferences = types.ModelPreferences
+type StopReason = types.StopReason
+type SamplingRequest = types.SamplingRequest
+type SamplingResponse = types.SamplingResponse
+type CreateMessageRequest = types.CreateMessageRequest
+type CreateMessageResponse = types.CreateMessageResponse
+
+// Re-export batch types
+type BatchCall = protocol.BatchCall
+type BatchResult = protocol.BatchResult
 
 // Re-export constants
 const (
 	RoleUser      = types.RoleUser
 	RoleAssistant = types.RoleAssistant
+
+	StopReasonEndTurn      = types.StopReasonEndTurn
+	StopReasonStopSequence = types.StopReasonStopSequence
+	StopReasonMaxTokens    = types.StopReasonMaxTokens
 )
 
 // Re-export helper functions
 var (
 	NewTextContent      = types.NewTextContent
+	NewImageContent     = types.NewImageContent
+	NewAudioContent     = types.NewAudioContent
+	NewEmbeddedResource = types.NewEmbeddedResource
 	NewToolResponse     = types.NewToolResponse
 	NewPromptMessage    = types.NewPromptMessage
 	NewPromptResponse   = types.NewPromptResponse
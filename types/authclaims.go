@@ -0,0 +1,23 @@
+package types
+
+import "context"
+
+// authClaimsContextKey is the unexported context key under which HTTP-based
+// transports store the claims a BearerAuthMiddleware extracted from an
+// inbound request's Authorization header
+type authClaimsContextKey struct{}
+
+// AuthClaims returns the claims a BearerAuthMiddleware attached to ctx, and
+// whether any were present. The concrete type is whatever the middleware's
+// validator returned, so callers type-assert to what they expect.
+func AuthClaims(ctx context.Context) (interface{}, bool) {
+	claims := ctx.Value(authClaimsContextKey{})
+	return claims, claims != nil
+}
+
+// WithAuthClaims attaches the claims produced by validating an inbound
+// request's credentials to ctx, so downstream tool handlers can make
+// authorization decisions without re-parsing the Authorization header
+func WithAuthClaims(ctx context.Context, claims interface{}) context.Context {
+	return context.WithValue(ctx, authClaimsContextKey{}, claims)
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"context"
+	"net/netip"
+)
+
+// clientIPContextKey is the unexported context key under which HTTP-based
+// transports store the resolved real client address
+type clientIPContextKey struct{}
+
+// ClientIP returns the real client address that an HTTP-based transport
+// resolved for the request behind ctx, and whether one was resolved. When a
+// transport is configured with trusted proxies, this is the genuine client
+// address rather than the address of the nearest reverse proxy.
+func ClientIP(ctx context.Context) (netip.Addr, bool) {
+	addr, ok := ctx.Value(clientIPContextKey{}).(netip.Addr)
+	return addr, ok
+}
+
+// WithClientIP attaches the resolved real client address to ctx so tool
+// handlers, rate limiting, and audit logs can key off the genuine client
+// rather than a reverse proxy
+func WithClientIP(ctx context.Context, addr netip.Addr) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, addr)
+}
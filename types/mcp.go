@@ -1,5 +1,10 @@
 package types
 
+import (
+	"encoding/base64"
+	"fmt"
+)
+
 // InitializeResponse is returned when initializing the server
 type InitializeResponse struct {
 	ProtocolVersion string             `json:"protocolVersion"`
@@ -16,10 +21,11 @@ type ServerInfo struct {
 
 // ServerCapabilities describes what the server can do
 type ServerCapabilities struct {
-	Tools     *ToolsCapability     `json:"tools,omitempty"`
-	Prompts   *PromptsCapability   `json:"prompts,omitempty"`
-	Resources *ResourcesCapability `json:"resources,omitempty"`
-	Logging   *LoggingCapability   `json:"logging,omitempty"`
+	Tools       *ToolsCapability       `json:"tools,omitempty"`
+	Prompts     *PromptsCapability     `json:"prompts,omitempty"`
+	Resources   *ResourcesCapability   `json:"resources,omitempty"`
+	Logging     *LoggingCapability     `json:"logging,omitempty"`
+	Completions *CompletionsCapability `json:"completions,omitempty"`
 }
 
 // ToolsCapability describes tool-related capabilities
@@ -41,6 +47,20 @@ type ResourcesCapability struct {
 // LoggingCapability describes logging capabilities
 type LoggingCapability struct{}
 
+// CompletionsCapability indicates the server supports completion/complete,
+// added in later protocol revisions
+type CompletionsCapability struct{}
+
+// ClientCapabilities describes what the client can do, advertised in the
+// initialize request
+type ClientCapabilities struct {
+	Sampling *SamplingCapability `json:"sampling,omitempty"`
+}
+
+// SamplingCapability indicates the client supports sampling/createMessage,
+// i.e. a server can ask it to run its LLM on the server's behalf
+type SamplingCapability struct{}
+
 // ToolsResponse is the response to a tools/list request
 type ToolsResponse struct {
 	Tools      []Tool  `json:"tools"`
@@ -52,6 +72,11 @@ type Tool struct {
 	Name        string                 `json:"name"`
 	Description *string                `json:"description,omitempty"`
 	InputSchema map[string]interface{} `json:"inputSchema"`
+	// OutputSchema and Annotations are only populated for clients that
+	// negotiated a protocol version new enough to define them - see
+	// mcpgo.WithCapabilityOverride.
+	OutputSchema map[string]interface{} `json:"outputSchema,omitempty"`
+	Annotations  map[string]interface{} `json:"annotations,omitempty"`
 }
 
 // ToolResponse is the result of a tool call
@@ -60,12 +85,15 @@ type ToolResponse struct {
 	IsError bool      `json:"isError,omitempty"`
 }
 
-// Content represents a piece of content in a response
+// Content represents a piece of content in a response. Type selects which
+// of the other fields are populated: "text" uses Text, "image" and "audio"
+// use base64-encoded Data plus MimeType, and "resource" uses Resource.
 type Content struct {
-	Type     string  `json:"type"`
-	Text     *string `json:"text,omitempty"`
-	Data     *string `json:"data,omitempty"`
-	MimeType *string `json:"mimeType,omitempty"`
+	Type     string           `json:"type"`
+	Text     *string          `json:"text,omitempty"`
+	Data     *string          `json:"data,omitempty"`
+	MimeType *string          `json:"mimeType,omitempty"`
+	Resource *ResourceContent `json:"resource,omitempty"`
 }
 
 // NewTextContent creates text content
@@ -76,6 +104,62 @@ func NewTextContent(text string) *Content {
 	}
 }
 
+// NewImageContent creates image content, base64-encoding data into the
+// content's Data field
+func NewImageContent(data []byte, mimeType string) *Content {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	return &Content{
+		Type:     "image",
+		Data:     &encoded,
+		MimeType: &mimeType,
+	}
+}
+
+// NewAudioContent creates audio content, base64-encoding data into the
+// content's Data field
+func NewAudioContent(data []byte, mimeType string) *Content {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	return &Content{
+		Type:     "audio",
+		Data:     &encoded,
+		MimeType: &mimeType,
+	}
+}
+
+// NewEmbeddedResource creates content that embeds a resource (e.g. a
+// resources/read result) directly in a tool result or prompt message
+func NewEmbeddedResource(resource ResourceContent) *Content {
+	return &Content{
+		Type:     "resource",
+		Resource: &resource,
+	}
+}
+
+// Validate reports whether c carries the fields its Type requires,
+// rejecting malformed content before it's serialized and sent to a peer.
+func (c Content) Validate() error {
+	switch c.Type {
+	case "text":
+		if c.Text == nil {
+			return fmt.Errorf("content type %q requires Text", c.Type)
+		}
+	case "image", "audio":
+		if c.Data == nil {
+			return fmt.Errorf("content type %q requires Data", c.Type)
+		}
+		if c.MimeType == nil {
+			return fmt.Errorf("content type %q requires MimeType", c.Type)
+		}
+	case "resource":
+		if c.Resource == nil {
+			return fmt.Errorf("content type %q requires Resource", c.Type)
+		}
+	default:
+		return fmt.Errorf("unknown content type %q", c.Type)
+	}
+	return nil
+}
+
 // NewToolResponse creates a new tool response
 func NewToolResponse(content ...*Content) *ToolResponse {
 	return &ToolResponse{
@@ -183,6 +267,25 @@ func NewResourceResponse(contents ...ResourceContent) *ResourceResponse {
 	}
 }
 
+// CancelledParams carries the payload of a notifications/cancelled message
+type CancelledParams struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// ProgressParams carries the payload of a notifications/progress message
+type ProgressParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Total         *float64    `json:"total,omitempty"`
+}
+
+// RequestMeta models the optional `_meta` field carried on request params,
+// used for out-of-band bookkeeping like progress tracking
+type RequestMeta struct {
+	ProgressToken interface{} `json:"progressToken,omitempty"`
+}
+
 // Helper function to convert []*Content to []Content
 func contentPtrSliceToSlice(ptrs []*Content) []Content {
 	result := make([]Content, len(ptrs))
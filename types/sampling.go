@@ -0,0 +1,57 @@
+package types
+
+// SamplingMessage is a single message in a sampling/createMessage conversation
+type SamplingMessage struct {
+	Role    MessageRole `json:"role"`
+	Content Content     `json:"content"`
+}
+
+// ModelHint suggests a specific model name or family for the client to
+// prefer when servicing a sampling request; clients may ignore hints they
+// don't recognize
+type ModelHint struct {
+	Name string `json:"name,omitempty"`
+}
+
+// ModelPreferences lets a server steer, without dictating, the client's
+// model selection for a sampling request. Priorities range 0-1, where
+// higher means more important
+type ModelPreferences struct {
+	Hints                []ModelHint `json:"hints,omitempty"`
+	CostPriority         *float64    `json:"costPriority,omitempty"`
+	SpeedPriority        *float64    `json:"speedPriority,omitempty"`
+	IntelligencePriority *float64    `json:"intelligencePriority,omitempty"`
+}
+
+// StopReason explains why the client's LLM stopped generating
+type StopReason string
+
+const (
+	StopReasonEndTurn      StopReason = "endTurn"
+	StopReasonStopSequence StopReason = "stopSequence"
+	StopReasonMaxTokens    StopReason = "maxTokens"
+)
+
+// SamplingRequest carries the payload of a sampling/createMessage request,
+// sent by a server to ask the connected client to run its LLM
+type SamplingRequest struct {
+	Messages         []SamplingMessage `json:"messages"`
+	ModelPreferences *ModelPreferences `json:"modelPreferences,omitempty"`
+	SystemPrompt     *string           `json:"systemPrompt,omitempty"`
+	MaxTokens        int               `json:"maxTokens"`
+}
+
+// SamplingResponse is the client's answer to a SamplingRequest
+type SamplingResponse struct {
+	Role       MessageRole `json:"role"`
+	Content    Content     `json:"content"`
+	Model      string      `json:"model"`
+	StopReason StopReason  `json:"stopReason,omitempty"`
+}
+
+// CreateMessageRequest and CreateMessageResponse are aliases for
+// SamplingRequest and SamplingResponse under the names the MCP spec uses
+// for the sampling/createMessage method, for callers that prefer to match
+// the spec's vocabulary.
+type CreateMessageRequest = SamplingRequest
+type CreateMessageResponse = SamplingResponse
@@ -1,6 +1,9 @@
 package types
 
-import "context"
+import (
+	"context"
+	"net/http"
+)
 
 // Transport defines the interface for MCP transports
 type Transport interface {
@@ -23,6 +26,17 @@ type Transport interface {
 	SetCloseHandler(handler func())
 }
 
+// BatchTransport is implemented by transports that can send several
+// JSON-RPC messages as a single wire-level batch (a JSON array), as
+// permitted by the JSON-RPC 2.0 spec. Transports that don't implement it
+// fall back to sending batch members one message at a time.
+type BatchTransport interface {
+	Transport
+
+	// SendBatch sends a batch of JSON-RPC messages as a single unit
+	SendBatch(ctx context.Context, msgs []*BaseJSONRPCMessage) error
+}
+
 // MessageHandler handles incoming messages
 type MessageHandler func(ctx context.Context, msg *BaseJSONRPCMessage)
 
@@ -31,3 +45,9 @@ type ErrorHandler func(error)
 
 // CloseHandler handles connection closure
 type CloseHandler func()
+
+// HTTPMiddleware wraps an http.Handler to add cross-cutting behavior -
+// logging, recovery, CORS, auth - to an HTTP-based transport. Transports
+// accepting these via WithMiddleware chain them in reverse registration
+// order, so the last one added wraps outermost and sees the request first.
+type HTTPMiddleware func(http.Handler) http.Handler